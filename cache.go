@@ -0,0 +1,115 @@
+package gonfig
+
+import "sync"
+
+// valueCache memoizes the converted results of the typed accessors.
+// Entries are keyed by accessor kind and path and dropped whenever their section
+// changes. A generation counter, bumped on every invalidation, keeps a lookup that
+// raced with a write from storing a value computed before the write.
+type valueCache struct {
+	mu      sync.Mutex
+	enabled bool
+	gen     uint64
+	entries map[cacheKey]cacheEntry
+}
+
+// cacheKey identifies a converted value by the accessor that produced it.
+type cacheKey struct {
+	kind string
+	path string
+}
+
+// cacheEntry is a converted value and the section it was read from.
+type cacheEntry struct {
+	section string
+	value   interface{}
+}
+
+// EnableValueCache turns memoization of GetString, GetInt, GetBool and GetFloat results
+// on or off. Cached values are invalidated by every write to their section, including
+// Set, Unset, MergeFrom, Refresh and RefreshSection. Paths bound to flags are never cached.
+// Disabling the cache discards all cached values.
+func (r *ConfigRegistry) EnableValueCache(enabled bool) {
+	r.cache.mu.Lock()
+	defer r.cache.mu.Unlock()
+
+	r.cache.enabled = enabled
+	r.cache.gen++
+	r.cache.entries = nil
+}
+
+// cached returns the cached value for an accessor kind and path, along with the
+// generation to pass to remember when the value has to be computed.
+func (r *ConfigRegistry) cached(kind, path string) (interface{}, uint64, bool) {
+	r.cache.mu.Lock()
+	entry, ok := r.cache.entries[cacheKey{kind, path}]
+	gen := r.cache.gen
+	r.cache.mu.Unlock()
+
+	if ok {
+		r.mu.RLock()
+		if r.metrics != nil {
+			r.metrics.ObserveGet(path, true)
+		}
+		r.mu.RUnlock()
+	}
+	return entry.value, gen, ok
+}
+
+// remember caches a converted value, unless the cache is disabled, the path is bound
+// to a flag or has a fallback, environment overrides are enabled, or the configuration
+// changed since gen was obtained from cached.
+// It is generic so the value is only boxed into an interface when it is stored.
+func remember[T any](r *ConfigRegistry, kind, path string, gen uint64, value T) {
+	r.cache.mu.Lock()
+	current := r.cache.enabled && r.cache.gen == gen
+	r.cache.mu.Unlock()
+	if !current {
+		return
+	}
+
+	r.mu.RLock()
+	_, bound := r.bindings[path]
+	overridden := r.envOverridePrefix != ""
+	fallback := r.hasFallback(path)
+	r.mu.RUnlock()
+	if bound || overridden || fallback {
+		return
+	}
+
+	r.cache.mu.Lock()
+	defer r.cache.mu.Unlock()
+
+	if !r.cache.enabled || r.cache.gen != gen {
+		return
+	}
+	if r.cache.entries == nil {
+		r.cache.entries = make(map[cacheKey]cacheEntry)
+	}
+	r.cache.entries[cacheKey{kind, path}] = cacheEntry{
+		section: r.pathCache.shared(path)[0],
+		value:   value,
+	}
+}
+
+// invalidate drops the cached values read from a section.
+func (r *ConfigRegistry) invalidate(section string) {
+	r.cache.mu.Lock()
+	defer r.cache.mu.Unlock()
+
+	r.cache.gen++
+	for key, entry := range r.cache.entries {
+		if entry.section == section {
+			delete(r.cache.entries, key)
+		}
+	}
+}
+
+// invalidateAll drops every cached value.
+func (r *ConfigRegistry) invalidateAll() {
+	r.cache.mu.Lock()
+	defer r.cache.mu.Unlock()
+
+	r.cache.gen++
+	r.cache.entries = nil
+}
@@ -0,0 +1,73 @@
+package gonfig
+
+import (
+	configContracts "github.com/centraunit/gonfig/contracts"
+)
+
+// Clone returns an independent copy of the registry that shares no mutable state with it.
+// Sections are deep-copied and loaders, flag bindings, enforced types, fallbacks, decode
+// hooks and weak typing, the attached and section schemas, array and env interpolation
+// settings, template sections, directories loaded with LoadDir, env files and whether
+// Refresh reloads them, the logger and the metrics observer are carried over, so the
+// clone refreshes from the same sources.
+// Structs bound with Bind stay bound to the original registry only, the value cache
+// starts empty, and polling is not started.
+// Example: candidate := Clone(); candidate.Set("app.feature", true)
+func (r *ConfigRegistry) Clone() configContracts.ConfigRegistry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	clone := &ConfigRegistry{
+		configs:        copySections(r.configs),
+		loaders:        make(map[string]configContracts.ConfigLoaderE, len(r.loaders)),
+		pathCache:      NewPathCache(),
+		schema:         r.schema,
+		bindings:       make(map[string]func() (interface{}, bool), len(r.bindings)),
+		bound:          make(map[string][]interface{}),
+		hooks:          append([]configContracts.DecodeHook(nil), r.hooks...),
+		dirs:           append([]configDir(nil), r.dirs...),
+		envFiles:       r.envFiles,
+		reloadEnv:      r.reloadEnv,
+		enforced:       r.enforced,
+		fallbacks:      r.fallbacks,
+		arraySeparator: r.arraySeparator,
+		arrayOmitEmpty: r.arrayOmitEmpty,
+		interpolateEnv: r.interpolateEnv,
+		strictTypes:    r.strictTypes,
+		origins:        copyOrigins(r.origins),
+		logger:         r.logger,
+		metrics:        r.metrics,
+
+		envOverridePrefix: r.envOverridePrefix,
+		layerSeq:          r.layerSeq,
+	}
+	for name, loader := range r.loaders {
+		clone.loaders[name] = loader
+	}
+	for path, binding := range r.bindings {
+		clone.bindings[path] = binding
+	}
+	for section := range r.templates {
+		if clone.templates == nil {
+			clone.templates = make(map[string]bool, len(r.templates))
+		}
+		clone.templates[section] = true
+	}
+	for section, schema := range r.schemas {
+		clone.RegisterSchema(section, schema)
+	}
+	// Layered loaders read their layers from the registry they were created for
+	for section, layers := range r.layers {
+		if clone.layers == nil {
+			clone.layers = make(map[string][]layer, len(r.layers))
+		}
+		clone.layers[section] = append([]layer(nil), layers...)
+		clone.loaders[section] = clone.layeredLoader(section)
+	}
+
+	r.cache.mu.Lock()
+	clone.cache.enabled = r.cache.enabled
+	r.cache.mu.Unlock()
+
+	return clone
+}
@@ -1,26 +1,109 @@
 package contracts
 
-import "reflect"
+import (
+	"context"
+	"flag"
+	"io"
+	"log/slog"
+	"reflect"
+	"time"
+)
 
 // ConfigLoader is a function type that returns configuration values
 type ConfigLoader func(registry ConfigRegistry) map[string]interface{}
 
+// ConfigLoaderE is a loader that can report failure, for example when a file can't be read
+type ConfigLoaderE func(registry ConfigRegistry) (map[string]interface{}, error)
+
+// DecodeHook converts a value of type from before Unmarshal assigns it to a field of type to.
+// It returns data unchanged for conversions it doesn't handle.
+type DecodeHook func(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error)
+
 // ConfigRegistry defines the interface for configuration management
 type ConfigRegistry interface {
 	// Core operations
 	Get(path string) (interface{}, error)
+	GetContext(ctx context.Context, path string) (interface{}, error)
+	GetMany(paths []string) (map[string]interface{}, error)
+	GetGlob(pattern string) ([]interface{}, error)
+	GetKeys(path string) ([]string, error)
+	ForEach(section string, fn func(key string, value interface{}) error) error
+	Flatten() map[string]interface{}
+	Export() map[string]interface{}
+	String() string
+	AllKeys() []string
 	GetString(path string, defaultValue ...string) (string, error)
 	GetInt(path string, defaultValue ...int) (int, error)
 	GetBool(path string, defaultValue ...bool) (bool, error)
 	GetFloat(path string, defaultValue ...float64) (float64, error)
+	GetBytes(path string, defaultValue ...int64) (int64, error)
+	GetDuration(path string, defaultValue ...time.Duration) (time.Duration, error)
 	GetStringArray(path string, defaultValue ...[]string) ([]string, error)
+	GetStringArraySep(path, sep string, defaultValue ...[]string) ([]string, error)
+	GetStringArrayOrDefault(path string, defaultValue []string) ([]string, error)
+	GetIntArray(path string, defaultValue ...[]int) ([]int, error)
+	GetFloatArray(path string, defaultValue ...[]float64) ([]float64, error)
+	GetMapArray(path string, defaultValue ...[]map[string]interface{}) ([]map[string]interface{}, error)
+	MustGetString(path string) string
+	MustGetInt(path string) int
+	MustGetBool(path string) bool
+	MustGetFloat(path string) float64
+	MustGetStringArray(path string) []string
 	Set(path string, value interface{}) error
+	SetMany(values map[string]interface{}) error
+	GetOrSet(path string, value interface{}) (interface{}, error)
+	Unset(path string) error
+	SetString(path string, value string) error
+	SetInt(path string, value int) error
+	SetBool(path string, value bool) error
+	SetFloat(path string, value float64) error
+	AttachSchema(schema ConfigSchema)
+	RegisterSchema(section string, schema ConfigSchema)
+	EnforceType(path string, kind reflect.Kind)
+	SetFallback(path, fallbackPath string) error
+	SetArraySeparator(sep string)
+	SetArrayOmitEmpty(omit bool)
+	SetLogger(logger *slog.Logger)
+	EnableValueCache(enabled bool)
+	SetMetricsObserver(obs MetricsObserver)
+	BindFlag(path string, f *flag.Flag)
+	ReadOnly() ConfigRegistry
+	Seal()
+	IsSealed() bool
+	MergeFrom(other map[string]map[string]interface{})
+	Snapshot() Snapshot
+	Clone() ConfigRegistry
+	Restore(s Snapshot) error
+	Transaction(fn func(tx ConfigRegistry) error) error
 	Register(name string, loader ConfigLoader)
-	Refresh()
+	RegisterE(name string, loader ConfigLoaderE) error
+	RegisterEnvPrefix(name, prefix string)
+	EnableEnvOverride(prefix string)
+	SetReloadEnvOnRefresh(reload bool)
+	RegisterTemplateSection(name string) error
+	RegisterMap(name string, data map[string]interface{})
+	AddLayer(section string, priority int, loader ConfigLoader)
+	Source(path string) (int, bool)
+	Origin(path string) (string, error)
+	Resolve(path string) (interface{}, string, error)
+	RegisterReader(name, format string, r io.Reader) error
+	LoadDir(dir, format string) error
+	RequireSections(names ...string) error
+	Refresh() error
+	RefreshSection(name string) error
+	StartPolling(interval time.Duration)
+	StopPolling()
 	Unmarshal(section string, v interface{}) error
 	UnmarshalKey(path string, v interface{}) error
+	UnmarshalStrict(section string, v interface{}) error
+	Marshal(section string, v interface{}) error
+	Bind(section string, v interface{}) error
+	RegisterDecodeHook(hook DecodeHook)
+	SetWeaklyTypedInput(weak bool)
 	GetEnvString(key string, defaultValue string) string
 	GetEnvInt(key string, defaultValue int) int
+	GetEnvFloat(key string, defaultValue float64) float64
+	GetEnvDuration(key string, defaultValue time.Duration) time.Duration
 	GetEnvBool(key string, defaultValue bool) bool
 	GetEnvStringArray(key string, defaultValue []string) []string
 }
@@ -28,15 +111,73 @@ type ConfigRegistry interface {
 // Schema defines the interface for configuration validation
 type ConfigSchema interface {
 	AddField(path string, field ConfigSchemaField)
+	AddRule(rule func(config map[string]interface{}) error)
+	SetFailFast(failFast bool)
 	Validate(config map[string]interface{}) error
+	ValidateField(path string, value interface{}) error
+	ValidateRegistry(registry ConfigRegistry) error
 }
 
 // SchemaField represents a field in the configuration schema
 type ConfigSchemaField struct {
+	// Type is the kind values must have; values of any kind are accepted if it is unset.
+	// Numbers of another kind match if they convert without loss, so a whole float64
+	// matches reflect.Int
 	Type      reflect.Kind
+	ElemType  reflect.Kind
 	Required  bool
 	Default   interface{}
 	Validator func(interface{}) error
+
+	// Pattern is a regular expression that string values must match
+	Pattern string
+	// Enum lists the values allowed for the field, compared with reflect.DeepEqual,
+	// except that numbers of different kinds match if they are equal
+	Enum []interface{}
+	// Min and Max bound numeric values, inclusive; nil leaves that side unbounded
+	Min *float64
+	Max *float64
+	// ParseAs parses values and the default in a format before they are checked,
+	// so a string such as "30s" can be validated as a duration
+	ParseAs ValueFormat
+}
+
+// ValueFormat is a format that schema fields parse values in, see ConfigSchemaField.ParseAs.
+type ValueFormat int
+
+const (
+	// FormatNone checks values as they are stored
+	FormatNone ValueFormat = iota
+	// FormatDuration parses durations such as "30s", or integers as nanoseconds, into a time.Duration
+	FormatDuration
+	// FormatByteSize parses sizes such as "512KB" or "2GiB", or integers as bytes, into an int64
+	FormatByteSize
+	// FormatInteger parses whole numbers of any numeric kind, or strings such as "8080", into an int64
+	FormatInteger
+	// FormatNumber parses numbers of any numeric kind, or strings such as "0.5", into a float64
+	FormatNumber
+)
+
+// MetricsObserver receives metrics about registry usage, for example to feed counters.
+// Implementations are called while the registry holds its lock and must not call back into it.
+type MetricsObserver interface {
+	// ObserveGet is called for every lookup with whether the path was found
+	ObserveGet(path string, hit bool)
+	// ObserveRefresh is called after every Refresh with the time it took
+	ObserveRefresh(duration time.Duration)
+}
+
+// Snapshot is an opaque, deep-copied capture of a registry's configuration.
+// It is created by ConfigRegistry.Snapshot and applied with ConfigRegistry.Restore.
+type Snapshot interface {
+	// Sections returns the names of the captured sections
+	Sections() []string
+}
+
+// ConfigDecoder is implemented by types that decode themselves from a raw configuration value.
+// Unmarshal passes the value to FromConfig instead of using the built-in conversions.
+type ConfigDecoder interface {
+	FromConfig(value interface{}) error
 }
 
 // PathCache defines the interface for path caching operations
@@ -0,0 +1,66 @@
+package gonfig
+
+import (
+	"reflect"
+
+	configContracts "github.com/centraunit/gonfig/contracts"
+)
+
+// ChangeKind describes how a value differs between two snapshots.
+type ChangeKind string
+
+// Kinds of change reported by Diff.
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// ConfigChange describes a leaf value that differs between two snapshots.
+// Old is nil for added values and New is nil for removed values.
+type ConfigChange struct {
+	Path string
+	Kind ChangeKind
+	Old  interface{}
+	New  interface{}
+}
+
+// Diff compares two snapshots leaf by leaf, using the paths Flatten would return,
+// and reports every value that was added, removed or modified going from a to b,
+// sorted by path. Snapshots not created by Snapshot are treated as empty.
+// Example: changes := Diff(before, registry.Snapshot())
+func Diff(a, b configContracts.Snapshot) []ConfigChange {
+	before, after := snapshotLeaves(a), snapshotLeaves(b)
+
+	paths := make(map[string]struct{}, len(before)+len(after))
+	for path := range before {
+		paths[path] = struct{}{}
+	}
+	for path := range after {
+		paths[path] = struct{}{}
+	}
+
+	var changes []ConfigChange
+	for _, path := range sortedKeys(paths) {
+		old, hadOld := before[path]
+		value, hasNew := after[path]
+		switch {
+		case !hadOld:
+			changes = append(changes, ConfigChange{Path: path, Kind: ChangeAdded, New: value})
+		case !hasNew:
+			changes = append(changes, ConfigChange{Path: path, Kind: ChangeRemoved, Old: old})
+		case !reflect.DeepEqual(old, value):
+			changes = append(changes, ConfigChange{Path: path, Kind: ChangeModified, Old: old, New: value})
+		}
+	}
+	return changes
+}
+
+// snapshotLeaves returns the leaf values of a snapshot keyed by their full path.
+func snapshotLeaves(s configContracts.Snapshot) map[string]interface{} {
+	snap, ok := s.(*snapshot)
+	if !ok || snap == nil {
+		return nil
+	}
+	return flattenSections(snap.configs)
+}
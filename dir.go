@@ -0,0 +1,141 @@
+package gonfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	configContracts "github.com/centraunit/gonfig/contracts"
+)
+
+// configDir is a directory of configuration files loaded with LoadDir.
+type configDir struct {
+	path   string
+	format string
+}
+
+// LoadDir registers a section for every file in dir with the extension of format,
+// named after the file without its extension, so LoadDir("config", "yaml") registers
+// config/database.yaml as the "database" section. The formats are those of
+// RegisterReader, and "yaml" also matches .yml files. Each section reads its file
+// again on Refresh, which also picks up files added to the directory since.
+// A file that fails to parse leaves its section empty, as with RegisterE, without
+// stopping the others from loading; the failures are returned joined into one error.
+// Example: LoadDir("config", "yaml")
+func (r *ConfigRegistry) LoadDir(dir, format string) error {
+	if _, err := configExtensions(format); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkSealed(dir); err != nil {
+		return err
+	}
+	src := configDir{path: dir, format: format}
+	entries, err := src.entries()
+	if err != nil {
+		return err
+	}
+	r.dirs = append(r.dirs, src)
+	return r.loadDir(src, entries, false)
+}
+
+// rescanDirs registers the files added to the directories loaded with LoadDir.
+// The caller must hold the write lock.
+func (r *ConfigRegistry) rescanDirs() error {
+	var errs []error
+	for _, src := range r.dirs {
+		entries, err := src.entries()
+		if err == nil {
+			err = r.loadDir(src, entries, true)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// loadDir registers a section for every configuration file among the entries of src.
+// With onlyNew set, files whose section already has a loader are skipped.
+// Returns the joined errors of the files that failed to load.
+// The caller must hold the write lock.
+func (r *ConfigRegistry) loadDir(src configDir, entries []os.DirEntry, onlyNew bool) error {
+	extensions, _ := configExtensions(src.format)
+
+	var errs []error
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || !containsString(extensions, strings.ToLower(ext)) {
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), ext)
+		if _, ok := r.loaders[name]; ok && onlyNew {
+			continue
+		}
+		if err := checkSectionName(name); err != nil {
+			r.logRegister(name, err)
+			errs = append(errs, err)
+			continue
+		}
+		delete(r.layers, name)
+		if err := r.register(name, fileLoader(filepath.Join(src.path, entry.Name()), src.format)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// entries returns the contents of the directory, sorted by file name.
+func (d configDir) entries() ([]os.DirEntry, error) {
+	entries, err := os.ReadDir(d.path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config directory '%s': %w", d.path, err)
+	}
+	return entries, nil
+}
+
+// fileLoader returns a loader that parses the file at path in the given format each time it is called.
+func fileLoader(path, format string) configContracts.ConfigLoaderE {
+	return func(registry configContracts.ConfigRegistry) (map[string]interface{}, error) {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		config, err := decodeConfig(format, file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading '%s': %w", path, err)
+		}
+		return config, nil
+	}
+}
+
+// configExtensions returns the file extensions of a configuration format.
+func configExtensions(format string) ([]string, error) {
+	switch strings.ToLower(format) {
+	case "json":
+		return []string{".json"}, nil
+	case "yaml", "yml":
+		return []string{".yaml", ".yml"}, nil
+	case "toml":
+		return []string{".toml"}, nil
+	}
+	return nil, fmt.Errorf("unsupported config format: %s", format)
+}
+
+// containsString reports whether values contains value.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
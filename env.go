@@ -0,0 +1,313 @@
+package gonfig
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	configContracts "github.com/centraunit/gonfig/contracts"
+	"github.com/centraunit/gonfig/internal/kv"
+	"github.com/joho/godotenv"
+)
+
+// validEnvName reports whether env can safely be used in an env file name.
+// Names may contain letters, digits, dashes and underscores.
+func validEnvName(env string) bool {
+	for _, r := range env {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return env != ""
+}
+
+// loadEnvFile loads ".env.<env>", falling back to ".env" if it doesn't exist, and
+// returns the name of the file it loaded.
+// Returns an error wrapping fs.ErrNotExist if neither file exists.
+func loadEnvFile(env string) (string, error) {
+	for _, name := range []string{".env." + env, ".env"} {
+		err := loadDotenv(name)
+		if err == nil {
+			return name, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("error loading %s file: %w", name, err)
+		}
+	}
+	return "", fmt.Errorf("error loading env file: neither .env.%s nor .env exists: %w", env, os.ErrNotExist)
+}
+
+// dotenvReference matches the variable references godotenv expands, such as ${NAME} or $NAME.
+var dotenvReference = regexp.MustCompile(`\$\{?([A-Z0-9_]+)`)
+
+// loadDotenv sets the variables defined in each env file, like godotenv.Load, without
+// overriding variables that are already set. Values can reference variables defined
+// earlier in the same file and, unlike with godotenv alone, variables of the existing
+// environment; escaped references such as \${NAME} are kept literally.
+func loadDotenv(names ...string) error {
+	dotenvVars.mu.Lock()
+	defer dotenvVars.mu.Unlock()
+
+	for _, name := range names {
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		values, err := parseDotenv(content)
+		if err != nil {
+			return err
+		}
+		for key, value := range values {
+			if _, ok := os.LookupEnv(key); !ok {
+				os.Setenv(key, value)
+				dotenvVars.set(key, name, value)
+			}
+		}
+	}
+	return nil
+}
+
+// dotenvVar records a variable set from an env file and the value it was set to.
+type dotenvVar struct {
+	file  string
+	value string
+}
+
+// dotenvVars records the variables set from env files, so reloading the files only
+// replaces variables they set and leaves the rest of the environment alone. The
+// environment belongs to the process, so the record is shared by every registry.
+var dotenvVars dotenvRecord
+
+// dotenvRecord maps the names of variables set from env files to where they came from.
+type dotenvRecord struct {
+	mu   sync.Mutex
+	vars map[string]dotenvVar
+}
+
+// set records that key was set to value from file. The caller must hold d.mu.
+func (d *dotenvRecord) set(key, file, value string) {
+	if d.vars == nil {
+		d.vars = make(map[string]dotenvVar)
+	}
+	d.vars[key] = dotenvVar{file: file, value: value}
+}
+
+// owns reports whether key still has the value file set it to. The caller must hold d.mu.
+func (d *dotenvRecord) owns(key, file, value string) bool {
+	v, ok := d.vars[key]
+	return ok && v.file == file && v.value == value
+}
+
+// reloadDotenv reads the env files again and applies their current contents to the
+// environment. Variables the files set before are updated, or unset if the files no
+// longer define them, and variables that aren't set are added. Variables set outside
+// the files, or changed since the files set them, are left alone. If a file can't be
+// read or parsed the environment isn't changed.
+func reloadDotenv(names ...string) error {
+	values := make(map[string]dotenvVar)
+	for _, name := range names {
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		parsed, err := parseDotenv(content)
+		if err != nil {
+			return fmt.Errorf("error parsing %s: %w", name, err)
+		}
+		for key, value := range parsed {
+			// As when loading, the first file defining a variable wins
+			if _, ok := values[key]; !ok {
+				values[key] = dotenvVar{file: name, value: value}
+			}
+		}
+	}
+
+	dotenvVars.mu.Lock()
+	defer dotenvVars.mu.Unlock()
+
+	for key, v := range values {
+		if current, ok := os.LookupEnv(key); ok && !dotenvVars.owns(key, v.file, current) {
+			continue
+		}
+		os.Setenv(key, v.value)
+		dotenvVars.set(key, v.file, v.value)
+	}
+	for key, v := range dotenvVars.vars {
+		if _, ok := values[key]; ok || !containsString(names, v.file) {
+			continue
+		}
+		if current, ok := os.LookupEnv(key); ok && current == v.value {
+			os.Unsetenv(key)
+		}
+		delete(dotenvVars.vars, key)
+	}
+	return nil
+}
+
+// SetReloadEnvOnRefresh makes Refresh read the env files the registry was created with
+// again before running the loaders, so loaders and environment overrides see edited
+// values without a restart. Variables the files set are updated, or unset when removed
+// from the files, while variables set by the OS or the container, or changed by the
+// application since, are never overridden. A file that can't be read is reported in
+// the error Refresh returns, and the loaders still run.
+// Example: SetReloadEnvOnRefresh(true)
+func (r *ConfigRegistry) SetReloadEnvOnRefresh(reload bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reloadEnv = reload
+}
+
+// parseDotenv parses the contents of an env file. godotenv only expands references to
+// variables defined in the file, so referenced variables that the file doesn't define are
+// seeded from the environment by prepending their definitions.
+func parseDotenv(content []byte) (map[string]string, error) {
+	values, err := godotenv.UnmarshalBytes(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var seed bytes.Buffer
+	seeded := make(map[string]bool)
+	for _, match := range dotenvReference.FindAllSubmatch(content, -1) {
+		name := string(match[1])
+		if _, ok := values[name]; ok || seeded[name] {
+			continue
+		}
+		if value, ok := os.LookupEnv(name); ok {
+			fmt.Fprintf(&seed, "%s=\"%s\"\n", name, dotenvEscaper.Replace(value))
+			seeded[name] = true
+		}
+	}
+	if len(seeded) == 0 {
+		return values, nil
+	}
+
+	expanded, err := godotenv.UnmarshalBytes(append(seed.Bytes(), content...))
+	if err != nil {
+		return nil, err
+	}
+	for key := range values {
+		values[key] = expanded[key]
+	}
+	return values, nil
+}
+
+// dotenvEscaper escapes a value for a double-quoted env file value.
+var dotenvEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`, "\r", `\r`)
+
+// expandEnv returns a copy of value with environment variable references expanded
+// in every string it contains. Maps and slices are copied rather than modified, since
+// loaders may return maps they keep using. The path, relative to value, of every
+// string that changed is passed to mark.
+func expandEnv(value interface{}, path string, mark func(path string)) interface{} {
+	switch v := value.(type) {
+	case string:
+		return expandString(v, path, mark)
+	case map[string]interface{}:
+		if v == nil {
+			return v
+		}
+		expanded := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			expanded[key] = expandEnv(item, joinKey(path, escapeKey(key)), mark)
+		}
+		return expanded
+	case []interface{}:
+		expanded := make([]interface{}, len(v))
+		for i, item := range v {
+			expanded[i] = expandEnv(item, joinKey(path, strconv.Itoa(i)), mark)
+		}
+		return expanded
+	case []string:
+		expanded := make([]string, len(v))
+		for i, item := range v {
+			expanded[i] = expandString(item, joinKey(path, strconv.Itoa(i)), mark)
+		}
+		return expanded
+	default:
+		return value
+	}
+}
+
+// expandString expands environment variable references in s, passing path to mark if it changed.
+func expandString(s, path string, mark func(path string)) string {
+	expanded := os.ExpandEnv(s)
+	if expanded != s {
+		mark(path)
+	}
+	return expanded
+}
+
+// EnableEnvOverride lets environment variables override any configuration path.
+// When a path is looked up, the variable named by the prefix, the section and the
+// rest of the path, uppercased and joined with underscores, is checked first and its
+// value is used if it is set, so with prefix "APP" the path "database.host" is
+// overridden by APP_DATABASE_HOST. Overrides also apply to paths that aren't configured.
+// Flags bound with BindFlag and values written with Set still take precedence.
+// Overrides apply to lookups of a single path, such as Get and the typed accessors,
+// not to Flatten, GetGlob or Unmarshal.
+// Typed accessor results aren't cached while overrides are enabled, since variables
+// can change at any time. Passing an empty prefix disables overrides.
+// Example: EnableEnvOverride("APP")
+func (r *ConfigRegistry) EnableEnvOverride(prefix string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.checkSealed("*") != nil {
+		return
+	}
+	r.envOverridePrefix = strings.TrimSuffix(prefix, "_")
+	r.invalidateAll()
+}
+
+// envOverride returns the value of the environment variable overriding a split path, if set.
+// The caller must hold the read or write lock.
+func (r *ConfigRegistry) envOverride(parts []string) (string, bool) {
+	if r.envOverridePrefix == "" || len(parts) < 2 {
+		return "", false
+	}
+	return os.LookupEnv(envOverrideName(r.envOverridePrefix, parts))
+}
+
+// envOverrideName returns the name of the environment variable overriding a split path.
+func envOverrideName(prefix string, parts []string) string {
+	var name strings.Builder
+	name.WriteString(prefix)
+	for _, part := range parts {
+		name.WriteByte('_')
+		name.WriteString(strings.ToUpper(part))
+	}
+	return name.String()
+}
+
+// RegisterEnvPrefix registers a section built from every environment variable starting
+// with prefix. The prefix is stripped, the rest of the name is lowercased, and "__"
+// separates nested levels, so with prefix "APP_" the variable APP_DB__HOST is available
+// as "<name>.db.host". Values are stored as strings, and Refresh re-scans the environment.
+// Example: RegisterEnvPrefix("app", "APP_")
+func (r *ConfigRegistry) RegisterEnvPrefix(name, prefix string) {
+	r.Register(name, func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return envSection(prefix)
+	})
+}
+
+// envSection nests the environment variables starting with prefix into a configuration map.
+func envSection(prefix string) map[string]interface{} {
+	pairs := make(map[string]string)
+	for _, entry := range os.Environ() {
+		key, value, _ := strings.Cut(entry, "=")
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		key = strings.ToLower(strings.TrimPrefix(key, prefix))
+		pairs[strings.ReplaceAll(key, "__", "/")] = value
+	}
+	return kv.Nest("", pairs)
+}
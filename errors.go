@@ -0,0 +1,74 @@
+package gonfig
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors identifying the category of a configuration error.
+// Errors returned by the registry wrap one of these, so callers can use errors.Is.
+var (
+	ErrSectionNotFound = errors.New("config section not found")
+	ErrKeyNotFound     = errors.New("key not found")
+	ErrTypeConversion  = errors.New("type conversion failed")
+	ErrInvalidPath     = errors.New("invalid config path")
+	ErrReadOnly        = errors.New("config registry is read-only")
+	ErrSealed          = errors.New("config registry is sealed")
+	ErrUnknownKey      = errors.New("unknown config key")
+
+	// ErrNilSection reports a section whose loader returned nil. It wraps
+	// ErrSectionNotFound, so checks for missing sections match it as well.
+	ErrNilSection = fmt.Errorf("%w: section is nil", ErrSectionNotFound)
+)
+
+// isMissing reports whether err means the path has no value, as opposed to a value
+// that can't be used.
+func isMissing(err error) bool {
+	return errors.Is(err, ErrKeyNotFound) || errors.Is(err, ErrSectionNotFound)
+}
+
+// PathError describes a failure to resolve or convert the value at a configuration path.
+// It is returned by the registry accessors and can be retrieved with errors.As.
+type PathError struct {
+	Path     string // Full path that was requested
+	Segment  string // Portion of the path where resolution failed
+	Kind     error  // Sentinel category, such as ErrKeyNotFound
+	Expected string // Expected type, set for type conversion failures
+	Actual   string // Actual type found, set for type conversion failures
+	Err      error  // Underlying cause, if any
+	msg      string
+}
+
+// Error returns the human-readable error message.
+func (e *PathError) Error() string {
+	return e.msg
+}
+
+// Unwrap exposes the sentinel category and the underlying cause to errors.Is and errors.As.
+func (e *PathError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// newPathError creates a PathError with a formatted message.
+// An error wrapped with %w in the message becomes the underlying cause.
+func newPathError(kind error, path, segment string, format string, args ...interface{}) *PathError {
+	err := fmt.Errorf(format, args...)
+	return &PathError{
+		Path:    path,
+		Segment: segment,
+		Kind:    kind,
+		Err:     errors.Unwrap(err),
+		msg:     err.Error(),
+	}
+}
+
+// newTypeError creates a PathError for a value that cannot be converted to the expected type.
+func newTypeError(path, expected string, value interface{}, format string, args ...interface{}) *PathError {
+	err := newPathError(ErrTypeConversion, path, path, format, args...)
+	err.Expected = expected
+	err.Actual = fmt.Sprintf("%T", value)
+	return err
+}
@@ -0,0 +1,50 @@
+package gonfig
+
+import (
+	"fmt"
+	"strings"
+)
+
+// String describes the registry by its section names and the number of keys in each,
+// such as "gonfig.ConfigRegistry{app: 3 keys, database: 5 keys}". Values are never
+// included, so printing or logging the registry can't leak credentials; use Export to
+// get the full contents.
+func (r *ConfigRegistry) String() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sections := make([]string, 0, len(r.configs))
+	for _, name := range sortedKeys(r.configs) {
+		config := r.configs[name]
+		switch {
+		case config == nil:
+			sections = append(sections, name+": nil")
+		case len(config) == 1:
+			sections = append(sections, name+": 1 key")
+		default:
+			sections = append(sections, fmt.Sprintf("%s: %d keys", name, len(config)))
+		}
+	}
+	return "gonfig.ConfigRegistry{" + strings.Join(sections, ", ") + "}"
+}
+
+// GoString returns the same redacted description as String, so formatting the
+// registry with %#v doesn't print its values either.
+func (r *ConfigRegistry) GoString() string {
+	return r.String()
+}
+
+// Export returns a deep copy of every section, keyed by section name, including any
+// secrets they hold. Unlike String it exposes every value, so the caller decides
+// where the contents may go.
+// Example: data, _ := json.Marshal(Export())
+func (r *ConfigRegistry) Export() map[string]interface{} {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	exported := make(map[string]interface{}, len(r.configs))
+	for name, config := range copySections(r.configs) {
+		exported[name] = config
+	}
+	return exported
+}
@@ -0,0 +1,66 @@
+package gonfig
+
+import "strings"
+
+// SetFallback makes lookups of path that find no value read fallbackPath instead, before
+// any default passed to an accessor applies, so "app.timeout" can inherit
+// "defaults.timeout" without duplicating it. Fallback paths can have fallbacks of their
+// own, forming a chain; a chain leading back to path is rejected with ErrInvalidPath.
+// Passing an empty fallbackPath removes the fallback. Typed accessor results for paths
+// with a fallback aren't cached, since they can depend on another section.
+// Example: SetFallback("app.timeout", "defaults.timeout")
+func (r *ConfigRegistry) SetFallback(path, fallbackPath string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkSealed(path); err != nil {
+		return err
+	}
+	// The map is replaced rather than modified, since transactions share it
+	fallbacks := make(map[string]string, len(r.fallbacks)+1)
+	for p, f := range r.fallbacks {
+		fallbacks[p] = f
+	}
+	key := joinParts(splitPath(path))
+	if fallbackPath == "" {
+		delete(fallbacks, key)
+	} else {
+		target := joinParts(splitPath(fallbackPath))
+		chain := []string{key}
+		for next, ok := target, true; ok; next, ok = fallbacks[next] {
+			chain = append(chain, next)
+			if next == key {
+				return newPathError(ErrInvalidPath, path, path, "fallback cycle: %s", strings.Join(chain, " -> "))
+			}
+		}
+		fallbacks[key] = target
+	}
+	r.fallbacks = fallbacks
+	r.invalidateAll()
+	return nil
+}
+
+// fallback returns the value of the fallback path of a split path that has no value.
+// It reports false if the path has no fallback or the fallback chain has no value either.
+// The caller must hold the read or write lock.
+func (r *ConfigRegistry) fallback(parts []string, err error) (interface{}, bool) {
+	if len(r.fallbacks) == 0 || !isMissing(err) {
+		return nil, false
+	}
+	path, ok := r.fallbacks[joinParts(parts)]
+	if !ok {
+		return nil, false
+	}
+	value, _, err := r.lookupSource(path)
+	return value, err == nil
+}
+
+// hasFallback reports whether a fallback is set for path.
+// The caller must hold the read or write lock.
+func (r *ConfigRegistry) hasFallback(path string) bool {
+	if len(r.fallbacks) == 0 {
+		return false
+	}
+	_, ok := r.fallbacks[joinParts(r.pathCache.shared(path))]
+	return ok
+}
@@ -0,0 +1,76 @@
+package gonfig
+
+import (
+	"flag"
+	"sync"
+
+	"github.com/spf13/pflag"
+)
+
+// BindFlag binds a command-line flag to a configuration path.
+// Once the flag has been explicitly set on the command line, lookups of the path
+// return the flag value instead of the loaded configuration, so flags take
+// precedence over environment variables and loaders. Whether the flag was set is
+// determined with flag.Visit, so a flag passed with its default value still wins.
+// The flag must belong to the default flag.CommandLine set.
+// Example: BindFlag("app.port", flag.Lookup("port"))
+func (r *ConfigRegistry) BindFlag(path string, f *flag.Flag) {
+	// flag.Visit walks every set flag, so it only runs again once more flags are set,
+	// which flag.NFlag reports cheaply; flags can't be unset
+	var (
+		mu      sync.Mutex
+		set     bool
+		visited = -1
+	)
+	r.bind(path, func() (interface{}, bool) {
+		mu.Lock()
+		if n := flag.NFlag(); !set && n != visited {
+			visited = n
+			flag.Visit(func(v *flag.Flag) {
+				if v == f {
+					set = true
+				}
+			})
+		}
+		isSet := set
+		mu.Unlock()
+		if !isSet {
+			return nil, false
+		}
+
+		if getter, ok := f.Value.(flag.Getter); ok {
+			return getter.Get(), true
+		}
+		return f.Value.String(), true
+	})
+}
+
+// BindPFlag binds a pflag flag to a configuration path.
+// It behaves like BindFlag, using the flag's Changed field to detect an explicit value.
+// Slice flags are read as a []string, so GetStringArray returns their elements.
+// It isn't part of the contracts.ConfigRegistry interface, so that consumers of the
+// interface don't depend on pflag; call it on the *ConfigRegistry.
+// Example: registry.(*gonfig.ConfigRegistry).BindPFlag("app.port", cmd.Flags().Lookup("port"))
+func (r *ConfigRegistry) BindPFlag(path string, f *pflag.Flag) {
+	r.bind(path, func() (interface{}, bool) {
+		if !f.Changed {
+			return nil, false
+		}
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			return slice.GetSlice(), true
+		}
+		return f.Value.String(), true
+	})
+}
+
+// bind registers a function that overrides lookups of path when it reports a value.
+func (r *ConfigRegistry) bind(path string, binding func() (interface{}, bool)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.checkSealed(path) != nil {
+		return
+	}
+	r.bindings[path] = binding
+	r.invalidate(r.pathCache.shared(path)[0])
+}
@@ -0,0 +1,47 @@
+package gonfig
+
+// Flatten returns every leaf value across all sections keyed by its full path,
+// such as "database.options.debug_mode". Slices and other non-map values are leaves,
+// and empty maps are kept as leaves so empty sections still appear. Keys containing
+// dots are escaped, so every returned path can be passed back to Get. Values are
+// deep copies, and the result only depends on the configuration, not on map ordering.
+// Example: Flatten()["app.name"]
+func (r *ConfigRegistry) Flatten() map[string]interface{} {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return flattenSections(r.configs)
+}
+
+// flattenSections returns every leaf value of configs keyed by its full path.
+func flattenSections(configs map[string]map[string]interface{}) map[string]interface{} {
+	flat := make(map[string]interface{})
+	for name, config := range configs {
+		flattenInto(flat, escapeKey(name), config)
+	}
+	return flat
+}
+
+// flattenInto adds the leaves of config to flat, prefixing their keys with prefix.
+func flattenInto(flat map[string]interface{}, prefix string, config map[string]interface{}) {
+	if len(config) == 0 {
+		flat[prefix] = map[string]interface{}{}
+		return
+	}
+	for key, value := range config {
+		path := prefix + "." + escapeKey(key)
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenInto(flat, path, nested)
+			continue
+		}
+		flat[path] = deepCopy(value)
+	}
+}
+
+// AllKeys returns the full path of every leaf value across all sections in sorted
+// order, matching the keys of Flatten. Go maps don't keep the order keys were declared
+// in, so sorting is what makes the output reproducible, for example in golden files.
+// Example: AllKeys() returns ["app.database.port", "app.name"]
+func (r *ConfigRegistry) AllKeys() []string {
+	return sortedKeys(r.Flatten())
+}
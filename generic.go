@@ -0,0 +1,75 @@
+package gonfig
+
+import (
+	"reflect"
+
+	configContracts "github.com/centraunit/gonfig/contracts"
+)
+
+// Get retrieves a typed value from the registry using dot notation.
+// It dispatches to the typed accessor for T, so the same conversions and default
+// handling apply. Supported types are string, int, int64, bool, float64 and []string.
+// Returns the zero value of T and an error if the value cannot be converted.
+// Example: port, err := gonfig.Get[int](registry, "database.port", 5432)
+func Get[T any](registry configContracts.ConfigRegistry, path string, defaultValue ...T) (T, error) {
+	var zero T
+	var value interface{}
+	var err error
+
+	switch defaults := any(defaultValue).(type) {
+	case []string:
+		value, err = registry.GetString(path, defaults...)
+	case []int:
+		value, err = registry.GetInt(path, defaults...)
+	case []int64:
+		value, err = getInt64(registry, path, defaults...)
+	case []bool:
+		value, err = registry.GetBool(path, defaults...)
+	case []float64:
+		value, err = registry.GetFloat(path, defaults...)
+	case [][]string:
+		value, err = registry.GetStringArray(path, defaults...)
+	default:
+		return zero, newPathError(ErrTypeConversion, path, path, "unsupported type %T for path '%s'", zero, path)
+	}
+
+	if err != nil {
+		return zero, err
+	}
+	return value.(T), nil
+}
+
+// GetStruct unmarshals a configuration section into a newly allocated T and returns it.
+// T must be a struct type; required fields and conversions are handled as by Unmarshal.
+// Returns the zero value of T and an error if the section is missing or can't be decoded.
+// Example: db, err := gonfig.GetStruct[DatabaseConfig](registry, "database")
+func GetStruct[T any](registry configContracts.ConfigRegistry, section string) (T, error) {
+	var value T
+	if typ := reflect.TypeOf(value); typ == nil || typ.Kind() != reflect.Struct {
+		return value, newPathError(ErrTypeConversion, section, section, "unsupported type %T for section '%s'", value, section)
+	}
+
+	if err := registry.Unmarshal(section, &value); err != nil {
+		var zero T
+		return zero, err
+	}
+	return value, nil
+}
+
+// getInt64 retrieves an int64 value without going through int, which GetInt
+// returns and which is only 32 bits wide on some platforms.
+func getInt64(registry configContracts.ConfigRegistry, path string, defaultValue ...int64) (int64, error) {
+	value, err := registry.Get(path)
+	if err != nil {
+		if len(defaultValue) > 0 && isMissing(err) {
+			return defaultValue[0], nil
+		}
+		return 0, err
+	}
+
+	n, err := toInteger(value)
+	if err != nil {
+		return 0, newTypeError(path, "int64", value, "cannot convert value '%v' at path '%s' to int64: %w", value, path, err)
+	}
+	return n, nil
+}
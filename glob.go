@@ -0,0 +1,85 @@
+package gonfig
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// GetGlob retrieves every value matching a path in which "*" segments stand for any
+// single key or array index at that level, including the section. Matches are returned
+// as deep copies, ordered by key and index, and paths that don't exist are skipped, so
+// an empty slice is returned when nothing matches. Recursive "**" matching is not
+// supported and is rejected as an invalid path.
+// Example: GetGlob("mail.mailers.*.transport")
+func (r *ConfigRegistry) GetGlob(pattern string) ([]interface{}, error) {
+	parts := r.pathCache.shared(pattern)
+	for _, part := range parts {
+		if part == "**" {
+			return nil, newPathError(ErrInvalidPath, pattern, part, "recursive wildcards are not supported: %s", pattern)
+		}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sections := []string{parts[0]}
+	if parts[0] == "*" {
+		sections = sortedKeys(r.configs)
+	}
+
+	matches := []interface{}{}
+	for _, section := range sections {
+		if config, ok := r.configs[section]; ok {
+			matches = collectGlob(matches, config, parts[1:])
+		}
+	}
+	return matches, nil
+}
+
+// collectGlob appends the values below value that match parts to matches.
+func collectGlob(matches []interface{}, value interface{}, parts []string) []interface{} {
+	if len(parts) == 0 {
+		return append(matches, deepCopy(value))
+	}
+
+	part, rest := parts[0], parts[1:]
+	if node, ok := value.(map[string]interface{}); ok {
+		if part != "*" {
+			if child, ok := node[part]; ok {
+				matches = collectGlob(matches, child, rest)
+			}
+			return matches
+		}
+		for _, key := range sortedKeys(node) {
+			matches = collectGlob(matches, node[key], rest)
+		}
+		return matches
+	}
+
+	slice := reflect.ValueOf(value)
+	if slice.Kind() != reflect.Slice {
+		return matches
+	}
+	if part != "*" {
+		index, err := strconv.Atoi(part)
+		if err == nil && index >= 0 && index < slice.Len() {
+			matches = collectGlob(matches, slice.Index(index).Interface(), rest)
+		}
+		return matches
+	}
+	for i := 0; i < slice.Len(); i++ {
+		matches = collectGlob(matches, slice.Index(i).Interface(), rest)
+	}
+	return matches
+}
+
+// sortedKeys returns the keys of a map in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
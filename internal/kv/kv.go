@@ -0,0 +1,43 @@
+package kv
+
+import "strings"
+
+// Nest builds a nested configuration map from slash-separated keys.
+// The prefix is stripped from every key before splitting, and keys ending
+// with a slash are treated as folders. When a key is both a value and a
+// folder, the folder wins so that nested values stay reachable.
+// Example: Nest("app/", map[string]string{"app/db/host": "localhost"})
+func Nest(prefix string, pairs map[string]string) map[string]interface{} {
+	config := make(map[string]interface{})
+	for key, value := range pairs {
+		key = strings.TrimPrefix(key, prefix)
+		folder := strings.HasSuffix(key, "/")
+		key = strings.Trim(key, "/")
+		if key == "" {
+			continue
+		}
+
+		parts := strings.Split(key, "/")
+		if folder {
+			parts = append(parts, "")
+		}
+		current := config
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := current[part].(map[string]interface{})
+			if !ok {
+				next = make(map[string]interface{})
+				current[part] = next
+			}
+			current = next
+		}
+
+		last := parts[len(parts)-1]
+		if folder {
+			continue
+		}
+		if _, isFolder := current[last].(map[string]interface{}); !isFolder {
+			current[last] = value
+		}
+	}
+	return config
+}
@@ -0,0 +1,125 @@
+package gonfig
+
+import (
+	"fmt"
+	"sort"
+
+	configContracts "github.com/centraunit/gonfig/contracts"
+)
+
+// layer is one source of a layered section and the contents it last loaded.
+type layer struct {
+	id       int
+	priority int
+	loader   configContracts.ConfigLoader
+	config   map[string]interface{}
+}
+
+// AddLayer adds a layer to a section and reloads the section from all of its layers.
+// Reads resolve each key from the highest-priority layer that has it, falling through
+// to lower layers, so a base, an environment and an override layer can be kept
+// independent; nested maps are merged key by key. Layers with equal priority resolve in
+// the order they were added, the later one winning. Refresh reloads every layer.
+// Registering a loader for the section with Register or RegisterE discards its layers.
+// Like other loaders, layers run without the lock and can read other sections.
+// Example: AddLayer("app", 0, baseLoader); AddLayer("app", 10, overrideLoader)
+func (r *ConfigRegistry) AddLayer(section string, priority int, loader configContracts.ConfigLoader) {
+	r.mu.Lock()
+	if r.checkSealed(section) != nil || checkSectionName(section) != nil {
+		r.mu.Unlock()
+		return
+	}
+	if r.layers == nil {
+		r.layers = make(map[string][]layer)
+	}
+	r.layerSeq++
+	layers := append([]layer(nil), r.layers[section]...)
+	layers = append(layers, layer{id: r.layerSeq, priority: priority, loader: loader})
+	sort.SliceStable(layers, func(i, j int) bool {
+		return layers[i].priority < layers[j].priority
+	})
+	r.layers[section] = layers
+	r.mu.Unlock()
+
+	// The layers run without the lock, so they can read other sections
+	layered := r.layeredLoader(section)
+	config, err := r.runLoader(layered)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.checkSealed(section) == nil {
+		_ = r.install(section, layered, config, err)
+	}
+}
+
+// Source reports the priority of the layer that provides the value at path.
+// It reports false if the path's section isn't built from layers or no layer has the path.
+// Example: Source("app.database.host")
+func (r *ConfigRegistry) Source(path string) (int, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.source(r.pathCache.shared(path), path)
+}
+
+// source reports the priority of the layer that provides the value at the split path.
+// The caller must hold the read or write lock.
+func (r *ConfigRegistry) source(parts []string, path string) (int, bool) {
+	if len(parts) < 2 {
+		return 0, false
+	}
+
+	layers := r.layers[parts[0]]
+	for i := len(layers) - 1; i >= 0; i-- {
+		if _, err := traverse(layers[i].config, parts[1:], path); err == nil {
+			return layers[i].priority, true
+		}
+	}
+	return 0, false
+}
+
+// layeredLoader returns a loader that loads every layer of a section and merges them
+// in priority order. The loaded contents of each layer are kept for Source, and only
+// replaced once every layer has loaded.
+// The loader takes the lock itself, so it must be called without it.
+func (r *ConfigRegistry) layeredLoader(section string) configContracts.ConfigLoaderE {
+	return func(registry configContracts.ConfigRegistry) (map[string]interface{}, error) {
+		r.mu.RLock()
+		layers := append([]layer(nil), r.layers[section]...)
+		r.mu.RUnlock()
+
+		configs := make(map[int]map[string]interface{}, len(layers))
+		merged := make(map[string]interface{})
+		for _, l := range layers {
+			config, err := loadLayer(registry, l)
+			if err != nil {
+				return nil, err
+			}
+			configs[l.id] = config
+			mergeMaps(merged, config)
+		}
+
+		// Layers may have been added or discarded while loading, so they are matched by id
+		r.mu.Lock()
+		for i, l := range r.layers[section] {
+			if config, ok := configs[l.id]; ok {
+				r.layers[section][i].config = config
+			}
+		}
+		r.mu.Unlock()
+		return merged, nil
+	}
+}
+
+// loadLayer runs the loader of a single layer, turning a panic into an error
+// that names the layer.
+func loadLayer(registry configContracts.ConfigRegistry, l layer) (config map[string]interface{}, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("layer with priority %d panicked: %v", l.priority, rec)
+		}
+	}()
+
+	return l.loader(registry), nil
+}
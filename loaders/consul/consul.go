@@ -0,0 +1,51 @@
+package consul
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	configContracts "github.com/centraunit/gonfig/contracts"
+	"github.com/centraunit/gonfig/internal/kv"
+	"github.com/hashicorp/consul/api"
+)
+
+// Register fetches every key under prefix from the Consul KV store and registers
+// the result as the named section. Key paths are split on "/" into nested maps
+// and values are stored as strings, so the registry's type conversion applies.
+// The section is re-fetched from Consul on every Refresh, keeping the previously
+// loaded values if the fetch fails. Returns an error if the initial fetch fails.
+// The prefix is treated as a folder, so "apps/web" doesn't match "apps/website".
+// Example: Register(registry, "features", "apps/web/features", client)
+func Register(registry configContracts.ConfigRegistry, name, prefix string, client *api.Client) error {
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	initial, err := fetch(client, prefix)
+	if err != nil {
+		return err
+	}
+
+	// Loaders can run concurrently, so the initial values are handed out atomically
+	var loaded atomic.Bool
+	return registry.RegisterE(name, func(registry configContracts.ConfigRegistry) (map[string]interface{}, error) {
+		if loaded.CompareAndSwap(false, true) {
+			return initial, nil
+		}
+		return fetch(client, prefix)
+	})
+}
+
+// fetch reads all keys under prefix and nests them into a configuration map.
+func fetch(client *api.Client, prefix string) (map[string]interface{}, error) {
+	pairs, _, err := client.KV().List(prefix, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error loading consul prefix '%s': %w", prefix, err)
+	}
+
+	values := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		values[pair.Key] = string(pair.Value)
+	}
+	return kv.Nest(prefix, values), nil
+}
@@ -0,0 +1,189 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	configContracts "github.com/centraunit/gonfig/contracts"
+	"github.com/centraunit/gonfig/internal/kv"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// resyncDelay is how long the watcher waits before retrying a failed full reload.
+const resyncDelay = time.Second
+
+var (
+	watchers   = make(map[watcherKey]*watcher)
+	watchersMu sync.Mutex
+)
+
+// watcherKey identifies a watch by its registry and section, so registries using the
+// same section name keep separate watches.
+type watcherKey struct {
+	registry configContracts.ConfigRegistry
+	name     string
+}
+
+// watcher keeps a section in sync with an etcd prefix.
+type watcher struct {
+	registry configContracts.ConfigRegistry
+	name     string
+	prefix   string
+	client   *clientv3.Client
+	onChange []func()
+
+	mu     sync.Mutex
+	values map[string]string
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Register loads every key under prefix from etcd and registers the result as the
+// named section. Key paths are split on "/" into nested maps and values are stored
+// as strings. A background watch updates the section whenever a key under the prefix
+// changes, invoking the onChange callbacks after each successful update. Registering
+// the same name with the same registry again replaces the previous watch. Returns an
+// error if the initial load or the registration fails, in which case no watch starts.
+// Example: Register(registry, "features", "/apps/web/features/", client)
+func Register(registry configContracts.ConfigRegistry, name, prefix string, client *clientv3.Client, onChange ...func()) error {
+	w := &watcher{
+		registry: registry,
+		name:     name,
+		prefix:   prefix,
+		client:   client,
+		onChange: onChange,
+		done:     make(chan struct{}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	revision, err := w.load(ctx)
+	if err != nil {
+		cancel()
+		return err
+	}
+	w.cancel = cancel
+
+	if err := registry.RegisterE(name, w.loader); err != nil {
+		cancel()
+		return err
+	}
+
+	StopWatch(registry, name)
+	watchersMu.Lock()
+	watchers[watcherKey{registry, name}] = w
+	watchersMu.Unlock()
+
+	go w.watch(ctx, revision)
+	return nil
+}
+
+// StopWatch stops the watch for the named section of registry and waits for it to exit.
+// The section keeps its last loaded values. It is a no-op if no watch is running.
+func StopWatch(registry configContracts.ConfigRegistry, name string) {
+	key := watcherKey{registry, name}
+	watchersMu.Lock()
+	w, ok := watchers[key]
+	delete(watchers, key)
+	watchersMu.Unlock()
+
+	if ok {
+		w.cancel()
+		<-w.done
+	}
+}
+
+// loader builds the section from the most recently watched values.
+func (w *watcher) loader(registry configContracts.ConfigRegistry) (map[string]interface{}, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return kv.Nest(w.prefix, w.values), nil
+}
+
+// load fetches all keys under the prefix and returns the revision they were read at.
+func (w *watcher) load(ctx context.Context) (int64, error) {
+	resp, err := w.client.Get(ctx, w.prefix, clientv3.WithPrefix())
+	if err != nil {
+		return 0, fmt.Errorf("error loading etcd prefix '%s': %w", w.prefix, err)
+	}
+
+	values := make(map[string]string, len(resp.Kvs))
+	for _, pair := range resp.Kvs {
+		values[string(pair.Key)] = string(pair.Value)
+	}
+
+	w.mu.Lock()
+	w.values = values
+	w.mu.Unlock()
+
+	return resp.Header.GetRevision(), nil
+}
+
+// watch applies changes under the prefix until the context is cancelled.
+// If the watch fails, for example after a compaction, the prefix is reloaded in full.
+func (w *watcher) watch(ctx context.Context, revision int64) {
+	defer close(w.done)
+
+	for {
+		watchChan := w.client.Watch(ctx, w.prefix, clientv3.WithPrefix(), clientv3.WithRev(revision+1))
+		for resp := range watchChan {
+			if resp.Err() != nil {
+				break
+			}
+			w.apply(resp.Events)
+			revision = resp.Header.GetRevision()
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		for {
+			var err error
+			if revision, err = w.load(ctx); err == nil {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(resyncDelay):
+			}
+		}
+		w.publish()
+	}
+}
+
+// apply updates the watched values with a batch of events and publishes the result.
+func (w *watcher) apply(events []*clientv3.Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	w.mu.Lock()
+	for _, event := range events {
+		key := string(event.Kv.Key)
+		if event.Type == clientv3.EventTypeDelete {
+			delete(w.values, key)
+			continue
+		}
+		w.values[key] = string(event.Kv.Value)
+	}
+	w.mu.Unlock()
+
+	w.publish()
+}
+
+// publish reloads the section so the registry picks up the watched values, then
+// runs the onChange callbacks. The callbacks are skipped if the section can't be
+// reloaded, for example because the registry was sealed.
+func (w *watcher) publish() {
+	if err := w.registry.RefreshSection(w.name); err != nil {
+		return
+	}
+	for _, callback := range w.onChange {
+		callback()
+	}
+}
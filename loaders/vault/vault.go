@@ -0,0 +1,57 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	configContracts "github.com/centraunit/gonfig/contracts"
+	vault "github.com/hashicorp/vault/api"
+)
+
+// Register reads the Vault secret at path and registers its data as the named section.
+// Secrets from a KV version 2 engine are unwrapped, so their fields are addressed
+// directly (e.g. "database.password"). The secret is re-read on every Refresh, which
+// also picks up values rotated after a lease expires; if a re-read fails the previous
+// values are kept. Returns an error if the initial read fails, reporting permission
+// errors distinctly so misconfigured tokens are easy to spot.
+// Example: Register(registry, "database", "secret/data/database", client)
+func Register(registry configContracts.ConfigRegistry, name, path string, client *vault.Client) error {
+	initial, err := read(client, path)
+	if err != nil {
+		return err
+	}
+
+	// Loaders can run concurrently, so the initial values are handed out atomically
+	var loaded atomic.Bool
+	return registry.RegisterE(name, func(registry configContracts.ConfigRegistry) (map[string]interface{}, error) {
+		if loaded.CompareAndSwap(false, true) {
+			return initial, nil
+		}
+		return read(client, path)
+	})
+}
+
+// read fetches the secret at path and returns its data.
+func read(client *vault.Client, path string) (map[string]interface{}, error) {
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		var respErr *vault.ResponseError
+		if errors.As(err, &respErr) && (respErr.StatusCode == http.StatusForbidden || respErr.StatusCode == http.StatusUnauthorized) {
+			return nil, fmt.Errorf("permission denied reading vault secret '%s': %w", path, err)
+		}
+		return nil, fmt.Errorf("error reading vault secret '%s': %w", path, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("vault secret not found: '%s'", path)
+	}
+
+	// KV version 2 nests the secret fields under "data" alongside "metadata"
+	if data, ok := secret.Data["data"].(map[string]interface{}); ok {
+		if _, hasMetadata := secret.Data["metadata"]; hasMetadata {
+			return data, nil
+		}
+	}
+	return secret.Data, nil
+}
@@ -0,0 +1,73 @@
+package gonfig
+
+import (
+	"log/slog"
+	"time"
+)
+
+// SetLogger sets the logger that registry operations are reported to.
+// Registrations and missed lookups are logged at debug level, refreshes at info level,
+// and loader failures and defaults returned for missing paths at warn level.
+// Passing nil, the default, disables logging.
+func (r *ConfigRegistry) SetLogger(logger *slog.Logger) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.logger = logger
+}
+
+// logRegister reports the outcome of registering a section.
+// The caller must hold the lock.
+func (r *ConfigRegistry) logRegister(section string, err error) {
+	if r.logger == nil {
+		return
+	}
+	if err != nil {
+		r.logger.Warn("config section registration failed", "section", section, "error", err)
+		return
+	}
+	r.logger.Debug("config section registered", "section", section)
+}
+
+// logReload reports the outcome of reloading a section.
+// The caller must hold the lock.
+func (r *ConfigRegistry) logReload(section string, err error) {
+	if r.logger == nil {
+		return
+	}
+	if err != nil {
+		r.logger.Warn("config section reload failed", "section", section, "error", err)
+		return
+	}
+	r.logger.Debug("config section reloaded", "section", section)
+}
+
+// logRefresh reports a completed Refresh.
+// The caller must hold the lock.
+func (r *ConfigRegistry) logRefresh(sections, failed int, duration time.Duration) {
+	if r.logger == nil {
+		return
+	}
+	r.logger.Info("config refreshed", "sections", sections, "failed", failed, "duration", duration)
+}
+
+// logMiss reports a lookup of a path that doesn't exist.
+// The caller must hold the lock.
+func (r *ConfigRegistry) logMiss(path string, err error) {
+	if r.logger == nil {
+		return
+	}
+	r.logger.Debug("config path not found", "path", path, "error", err)
+}
+
+// logDefault reports that a default value was returned for a missing path.
+func (r *ConfigRegistry) logDefault(path string, err error) {
+	r.mu.RLock()
+	logger := r.logger
+	r.mu.RUnlock()
+
+	if logger == nil {
+		return
+	}
+	logger.Warn("config path missing, using default", "path", path, "error", err)
+}
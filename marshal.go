@@ -0,0 +1,178 @@
+package gonfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Marshal replaces a configuration section with the fields of a struct.
+// Fields follow the same tag rules as Unmarshal: keys come from the `config` tag or the
+// lowercased field name, `config:"-"` fields are skipped, dotted keys create nested maps,
+// nested structs become maps and untagged embedded structs are flattened into the
+// outer level. Fields tagged `omitempty:"true"` are skipped when they hold their zero
+// value, and nil pointers are always skipped. Durations are stored as strings like
+// "1m30s" and times are formatted with the `timeformat` tag or RFC3339, so the section
+// unmarshals back into the same struct. A section that has a loader is overwritten
+// again by its next reload.
+// Example: Marshal("database", DatabaseConfig{Host: "localhost", Port: 5432})
+func (r *ConfigRegistry) Marshal(section string, v interface{}) error {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return fmt.Errorf("marshal source must be a struct or a non-nil pointer to one")
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return fmt.Errorf("marshal source must be a struct or a non-nil pointer to one")
+	}
+
+	config := make(map[string]interface{})
+	if err := marshalInto(config, val); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkSealed(section); err != nil {
+		return err
+	}
+	r.store(section, config)
+	r.resetOrigins(section, originMarshal)
+	return nil
+}
+
+// marshalInto writes the fields of a struct into config.
+func marshalInto(config map[string]interface{}, val reflect.Value) error {
+	typ := val.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		fieldVal := val.Field(i)
+
+		// Embedded structs without a tag write their fields to the same level
+		if field.Anonymous && field.Tag.Get("config") == "" {
+			embedded := fieldVal
+			if embedded.Kind() == reflect.Ptr && embedded.Type().Elem().Kind() == reflect.Struct {
+				if embedded.IsNil() {
+					continue
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if err := marshalInto(config, embedded); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		key := field.Tag.Get("config")
+		if key == "" {
+			key = strings.ToLower(field.Name)
+		}
+		if key == "-" {
+			continue
+		}
+		if field.Tag.Get("omitempty") == "true" && fieldVal.IsZero() {
+			continue
+		}
+
+		value, ok, err := marshalValue(fieldVal, field.Tag)
+		if err != nil {
+			return fmt.Errorf("error marshaling field '%s': %w", key, err)
+		}
+		if !ok {
+			continue
+		}
+		if err := setValue(config, splitPath(key), value, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// marshalValue converts a field value into its configuration representation.
+// It reports false for nil pointers and interfaces, which have no value to store.
+func marshalValue(val reflect.Value, tag reflect.StructTag) (interface{}, bool, error) {
+	switch val.Type() {
+	case durationType:
+		return time.Duration(val.Int()).String(), true, nil
+
+	case timeType:
+		layout := tag.Get("timeformat")
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		return val.Interface().(time.Time).Format(layout), true, nil
+	}
+
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if val.IsNil() {
+			return nil, false, nil
+		}
+		return marshalValue(val.Elem(), tag)
+
+	case reflect.Struct:
+		nested := make(map[string]interface{})
+		if err := marshalInto(nested, val); err != nil {
+			return nil, false, err
+		}
+		return nested, true, nil
+
+	case reflect.Slice, reflect.Array:
+		if val.Kind() == reflect.Slice && val.IsNil() {
+			return nil, false, nil
+		}
+		if val.Type().Elem().Kind() == reflect.String {
+			items := make([]string, val.Len())
+			for i := range items {
+				items[i] = val.Index(i).String()
+			}
+			return items, true, nil
+		}
+		items := make([]interface{}, val.Len())
+		for i := range items {
+			item, _, err := marshalValue(val.Index(i), "")
+			if err != nil {
+				return nil, false, err
+			}
+			items[i] = item
+		}
+		return items, true, nil
+
+	case reflect.Map:
+		if val.Type().Key().Kind() != reflect.String {
+			return nil, false, fmt.Errorf("unsupported map key type: %v", val.Type().Key())
+		}
+		if val.IsNil() {
+			return nil, false, nil
+		}
+		nested := make(map[string]interface{}, val.Len())
+		iter := val.MapRange()
+		for iter.Next() {
+			item, ok, err := marshalValue(iter.Value(), "")
+			if err != nil {
+				return nil, false, err
+			}
+			if ok {
+				nested[iter.Key().String()] = item
+			}
+		}
+		return nested, true, nil
+
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		return nil, false, fmt.Errorf("unsupported field type: %v", val.Type())
+
+	default:
+		return val.Interface(), true, nil
+	}
+}
@@ -0,0 +1,14 @@
+package gonfig
+
+import configContracts "github.com/centraunit/gonfig/contracts"
+
+// SetMetricsObserver sets the observer notified of lookups and refreshes.
+// Every Get, including those made by the typed accessors, reports its path and whether
+// it was found, and every Refresh reports its duration. Passing nil, the default,
+// disables observation.
+func (r *ConfigRegistry) SetMetricsObserver(obs configContracts.MetricsObserver) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.metrics = obs
+}
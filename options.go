@@ -0,0 +1,50 @@
+package gonfig
+
+import "log/slog"
+
+// Option configures a registry created by NewConfigRegistry.
+type Option func(*options)
+
+// options collects the settings applied by Option values.
+type options struct {
+	envFiles       []string
+	envFilesSet    bool
+	logger         *slog.Logger
+	interpolateEnv bool
+	arraySeparator string
+}
+
+// WithEnvFiles loads the given env files instead of ".env.<env>" and ".env".
+// Unlike the default files, every listed file must exist. Passing no files skips
+// env file loading entirely, which suits libraries that must not touch the process environment.
+// Example: WithEnvFiles("config/base.env", "config/local.env")
+func WithEnvFiles(files ...string) Option {
+	return func(o *options) {
+		o.envFiles = files
+		o.envFilesSet = true
+	}
+}
+
+// WithLogger sets the logger registry operations are reported to, as SetLogger does.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithEnvInterpolation expands ${VAR} and $VAR references to environment variables
+// in the string values returned by loaders. Values are expanded each time a section
+// is loaded, so Refresh picks up changed variables. Undefined variables expand to "".
+func WithEnvInterpolation() Option {
+	return func(o *options) {
+		o.interpolateEnv = true
+	}
+}
+
+// WithArraySeparator sets the separator GetStringArray splits string values on,
+// as SetArraySeparator does.
+func WithArraySeparator(sep string) Option {
+	return func(o *options) {
+		o.arraySeparator = sep
+	}
+}
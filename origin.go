@@ -0,0 +1,147 @@
+package gonfig
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Origin tags reported by Origin.
+const (
+	originSet     = "set"
+	originMerge   = "merge"
+	originMarshal = "marshal"
+	originEnv     = "env"
+	originFlag    = "flag"
+	originDefault = "default"
+)
+
+// Origin reports where the value at path came from, to help diagnose precedence.
+// It returns "loader:<section>" for values loaded by the section's loader,
+// "layer:<priority>" for sections built with AddLayer, "env" for values produced by
+// environment interpolation or overridden with EnableEnvOverride, "set" for values written with Set or its variants,
+// "merge" for values written by MergeFrom, "marshal" for sections written by Marshal,
+// and "flag" for paths bound to a flag that was set. Writes to a path also become the
+// origin of everything below it, and reloading a section resets its origins.
+// Defaults passed to the typed accessors are never stored, so Origin returns the
+// lookup error for a path that only has a default; Resolve reports schema defaults.
+// Example: Origin("database.host")
+func (r *ConfigRegistry) Origin(path string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, origin, err := r.resolve(path, false)
+	return origin, err
+}
+
+// storedOrigin returns the origin of the value stored at path, which has been split into parts.
+// The caller must hold the read or write lock.
+func (r *ConfigRegistry) storedOrigin(path string, parts []string) string {
+	if tag, ok := r.recordedOrigin(parts); ok {
+		return tag
+	}
+	if priority, ok := r.source(parts, path); ok {
+		return fmt.Sprintf("layer:%d", priority)
+	}
+	return "loader:" + parts[0]
+}
+
+// recordedOrigin returns the origin recorded for the split path or the closest of its parents.
+// The caller must hold the read or write lock.
+func (r *ConfigRegistry) recordedOrigin(parts []string) (string, bool) {
+	recorded := r.origins[parts[0]]
+	if len(recorded) == 0 {
+		return "", false
+	}
+	for i := len(parts); i > 1; i-- {
+		if tag, ok := recorded[joinParts(parts[1:i])]; ok {
+			return tag, true
+		}
+	}
+	tag, ok := recorded[""]
+	return tag, ok
+}
+
+// explicitlySet reports whether the value stored at the split path was written with Set.
+// The caller must hold the read or write lock.
+func (r *ConfigRegistry) explicitlySet(parts []string) bool {
+	tag, ok := r.recordedOrigin(parts)
+	return ok && tag == originSet
+}
+
+// recordOrigin records the origin of a path within a section, replacing the origins
+// recorded for anything below it. An empty path records the origin of the whole section.
+// The caller must hold the write lock.
+func (r *ConfigRegistry) recordOrigin(section, path, tag string) {
+	r.forgetOrigin(section, path)
+	if r.origins == nil {
+		r.origins = make(map[string]map[string]string)
+	}
+	if r.origins[section] == nil {
+		r.origins[section] = make(map[string]string)
+	}
+	r.origins[section][path] = tag
+}
+
+// forgetOrigin drops the origins recorded for a path within a section and anything below it.
+// The caller must hold the write lock.
+func (r *ConfigRegistry) forgetOrigin(section, path string) {
+	recorded := r.origins[section]
+	if len(recorded) == 0 {
+		return
+	}
+	delete(recorded, path)
+	prefix := path + "."
+	for recordedPath := range recorded {
+		if path == "" || strings.HasPrefix(recordedPath, prefix) {
+			delete(recorded, recordedPath)
+		}
+	}
+}
+
+// resetOrigins drops every origin recorded for a section, then records tag as the
+// origin of the whole section unless it is empty.
+// The caller must hold the write lock.
+func (r *ConfigRegistry) resetOrigins(section, tag string) {
+	delete(r.origins, section)
+	if tag != "" {
+		r.recordOrigin(section, "", tag)
+	}
+}
+
+// recordLeafOrigins records tag as the origin of every leaf value in config.
+// The caller must hold the write lock.
+func (r *ConfigRegistry) recordLeafOrigins(section, path string, config map[string]interface{}, tag string) {
+	for key, value := range config {
+		keyPath := joinKey(path, escapeKey(key))
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			r.recordLeafOrigins(section, keyPath, nested, tag)
+			continue
+		}
+		r.recordOrigin(section, keyPath, tag)
+	}
+}
+
+// joinParts joins path parts back into a path, escaping each part.
+func joinParts(parts []string) string {
+	escaped := make([]string, len(parts))
+	for i, part := range parts {
+		escaped[i] = escapeKey(part)
+	}
+	return strings.Join(escaped, ".")
+}
+
+// copyOrigins returns a copy of the recorded origins.
+func copyOrigins(origins map[string]map[string]string) map[string]map[string]string {
+	if origins == nil {
+		return nil
+	}
+	copied := make(map[string]map[string]string, len(origins))
+	for section, recorded := range origins {
+		copiedSection := make(map[string]string, len(recorded))
+		for path, tag := range recorded {
+			copiedSection[path] = tag
+		}
+		copied[section] = copiedSection
+	}
+	return copied
+}
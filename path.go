@@ -16,12 +16,90 @@ func NewPathCache() *PathCache {
 }
 
 // Get retrieves or creates a split path.
+// The returned slice is a copy, so callers are free to modify it.
 func (pc *PathCache) Get(path string) []string {
+	parts := pc.shared(path)
+	result := make([]string, len(parts))
+	copy(result, parts)
+	return result
+}
+
+// shared retrieves or creates a split path without copying.
+// The returned slice is owned by the cache and must never be modified.
+func (pc *PathCache) shared(path string) []string {
 	if cached, ok := pc.cache.Load(path); ok {
 		return cached.([]string)
 	}
 
-	parts := strings.Split(path, ".")
+	parts := splitPath(path)
 	pc.cache.Store(path, parts)
 	return parts
 }
+
+// keyEscaper escapes the characters splitPath treats specially within a key.
+var keyEscaper = strings.NewReplacer(`\`, `\\`, ".", `\.`, "[", `\[`)
+
+// escapeKey escapes a single key so it can be joined into a path that splitPath
+// splits back into the same parts.
+func escapeKey(key string) string {
+	if !strings.ContainsAny(key, `\.[`) {
+		return key
+	}
+	return keyEscaper.Replace(key)
+}
+
+// joinKey appends a key to a path, which may be empty. The key is used as is,
+// so callers escape map keys with escapeKey first.
+func joinKey(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// splitPath splits a dot-notation path into its parts.
+// Keys containing dots can be addressed by escaping the dot with a backslash
+// (servers.api\.example\.com.port) or by quoting the key in brackets
+// (servers["api.example.com"].port).
+func splitPath(path string) []string {
+	if !strings.ContainsAny(path, `\[`) {
+		return strings.Split(path, ".")
+	}
+
+	var parts []string
+	var current strings.Builder
+	// closed is set after a bracketed key, so the following dot doesn't emit an empty part
+	closed := false
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		switch {
+		case c == '\\' && i+1 < len(path):
+			i++
+			current.WriteByte(path[i])
+			closed = false
+		case c == '.':
+			if !closed {
+				parts = append(parts, current.String())
+				current.Reset()
+			}
+			closed = false
+		case c == '[' && strings.HasPrefix(path[i+1:], `"`) && strings.Contains(path[i+2:], `"]`):
+			if current.Len() > 0 {
+				parts = append(parts, current.String())
+				current.Reset()
+			}
+			end := strings.Index(path[i+2:], `"]`)
+			parts = append(parts, path[i+2:i+2+end])
+			i += end + 3
+			closed = true
+		default:
+			current.WriteByte(c)
+			closed = false
+		}
+	}
+	if !closed {
+		parts = append(parts, current.String())
+	}
+
+	return parts
+}
@@ -0,0 +1,53 @@
+package gonfig
+
+import "time"
+
+// StartPolling periodically calls Refresh on a background goroutine.
+// Calling it while polling is already running, or with a non-positive interval,
+// has no effect. Use StopPolling to stop the goroutine.
+// Example: StartPolling(30 * time.Second)
+func (r *ConfigRegistry) StartPolling(interval time.Duration) {
+	r.pollMu.Lock()
+	defer r.pollMu.Unlock()
+
+	if r.pollStop != nil || interval <= 0 {
+		return
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	r.pollStop = stop
+	r.pollDone = done
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				_ = r.Refresh()
+			}
+		}
+	}()
+}
+
+// StopPolling stops the polling goroutine started by StartPolling and waits for it to exit.
+// It has no effect if polling is not running.
+func (r *ConfigRegistry) StopPolling() {
+	r.pollMu.Lock()
+	defer r.pollMu.Unlock()
+
+	if r.pollStop == nil {
+		return
+	}
+
+	close(r.pollStop)
+	<-r.pollDone
+	r.pollStop = nil
+	r.pollDone = nil
+}
@@ -0,0 +1,90 @@
+package gonfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// RegisterReader registers a section parsed from r in the given format: "json", "yaml"
+// (or "yml") or "toml". The reader is consumed once, so the parsed contents are kept
+// and Refresh resets the section to them rather than reading again, as with RegisterMap.
+// Returns an error if the format is unknown or the input can't be parsed as an object,
+// in which case no section is registered.
+// Example: RegisterReader("app", "yaml", bytes.NewReader(appYAML))
+func (r *ConfigRegistry) RegisterReader(name, format string, reader io.Reader) error {
+	config, err := decodeConfig(format, reader)
+	if err != nil {
+		return fmt.Errorf("error reading section '%s': %w", name, err)
+	}
+
+	return r.RegisterE(name, mapLoader(config))
+}
+
+// decodeConfig parses a configuration object from reader.
+// Values are normalized to the types the typed accessors expect.
+func decodeConfig(format string, reader io.Reader) (map[string]interface{}, error) {
+	var config map[string]interface{}
+	switch strings.ToLower(format) {
+	case "json":
+		if err := json.NewDecoder(reader).Decode(&config); err != nil {
+			return nil, fmt.Errorf("invalid json: %w", err)
+		}
+	case "yaml", "yml":
+		if err := yaml.NewDecoder(reader).Decode(&config); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("invalid yaml: %w", err)
+		}
+	case "toml":
+		if _, err := toml.NewDecoder(reader).Decode(&config); err != nil {
+			return nil, fmt.Errorf("invalid toml: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config format: %s", format)
+	}
+
+	if config == nil {
+		return make(map[string]interface{}), nil
+	}
+	return normalizeDecoded(config).(map[string]interface{}), nil
+}
+
+// normalizeDecoded converts decoder-specific types into the ones loaders usually return:
+// maps with non-string keys get string keys, and int64 values that fit become int.
+func normalizeDecoded(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalizeDecoded(item)
+		}
+		return v
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[fmt.Sprint(key)] = normalizeDecoded(item)
+		}
+		return converted
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeDecoded(item)
+		}
+		return v
+	case []map[string]interface{}:
+		converted := make([]interface{}, len(v))
+		for i, item := range v {
+			converted[i] = normalizeDecoded(item)
+		}
+		return converted
+	case int64:
+		if v >= math.MinInt && v <= math.MaxInt {
+			return int(v)
+		}
+		return v
+	default:
+		return value
+	}
+}
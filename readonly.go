@@ -0,0 +1,411 @@
+package gonfig
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"time"
+
+	configContracts "github.com/centraunit/gonfig/contracts"
+)
+
+// readOnlyRegistry wraps a registry and rejects every mutation.
+// Methods that return an error fail with ErrReadOnly, the others are ignored.
+type readOnlyRegistry struct {
+	registry configContracts.ConfigRegistry
+}
+
+// readOnlyError reports a rejected write to path.
+func readOnlyError(path string) error {
+	return fmt.Errorf("%w: cannot modify '%s'", ErrReadOnly, path)
+}
+
+// Get retrieves a value from the underlying registry.
+func (r *readOnlyRegistry) Get(path string) (interface{}, error) {
+	return r.registry.Get(path)
+}
+
+// GetGlob retrieves every value of the underlying registry matching a wildcard path.
+func (r *readOnlyRegistry) GetGlob(pattern string) ([]interface{}, error) {
+	return r.registry.GetGlob(pattern)
+}
+
+// GetKeys returns the sorted keys of a map in the underlying registry.
+func (r *readOnlyRegistry) GetKeys(path string) ([]string, error) {
+	return r.registry.GetKeys(path)
+}
+
+// RequireSections checks that the named sections are present in the underlying registry.
+func (r *readOnlyRegistry) RequireSections(names ...string) error {
+	return r.registry.RequireSections(names...)
+}
+
+// ForEach iterates over the top-level keys of a section of the underlying registry.
+func (r *readOnlyRegistry) ForEach(section string, fn func(key string, value interface{}) error) error {
+	return r.registry.ForEach(section, fn)
+}
+
+// AllKeys returns the sorted path of every leaf value of the underlying registry.
+func (r *readOnlyRegistry) AllKeys() []string {
+	return r.registry.AllKeys()
+}
+
+// Flatten returns every leaf value of the underlying registry keyed by its full path.
+func (r *readOnlyRegistry) Flatten() map[string]interface{} {
+	return r.registry.Flatten()
+}
+
+// Export returns a deep copy of every section of the underlying registry.
+func (r *readOnlyRegistry) Export() map[string]interface{} {
+	return r.registry.Export()
+}
+
+// String describes the underlying registry without its values.
+func (r *readOnlyRegistry) String() string {
+	return r.registry.String()
+}
+
+// GetContext retrieves a value from the underlying registry, honoring cancellation.
+func (r *readOnlyRegistry) GetContext(ctx context.Context, path string) (interface{}, error) {
+	return r.registry.GetContext(ctx, path)
+}
+
+// GetMany retrieves several values from the underlying registry.
+func (r *readOnlyRegistry) GetMany(paths []string) (map[string]interface{}, error) {
+	return r.registry.GetMany(paths)
+}
+
+// GetString retrieves a string value from the underlying registry.
+func (r *readOnlyRegistry) GetString(path string, defaultValue ...string) (string, error) {
+	return r.registry.GetString(path, defaultValue...)
+}
+
+// GetInt retrieves an integer value from the underlying registry.
+func (r *readOnlyRegistry) GetInt(path string, defaultValue ...int) (int, error) {
+	return r.registry.GetInt(path, defaultValue...)
+}
+
+// GetBool retrieves a boolean value from the underlying registry.
+func (r *readOnlyRegistry) GetBool(path string, defaultValue ...bool) (bool, error) {
+	return r.registry.GetBool(path, defaultValue...)
+}
+
+// GetFloat retrieves a float64 value from the underlying registry.
+func (r *readOnlyRegistry) GetFloat(path string, defaultValue ...float64) (float64, error) {
+	return r.registry.GetFloat(path, defaultValue...)
+}
+
+// GetBytes retrieves a byte count from the underlying registry.
+func (r *readOnlyRegistry) GetBytes(path string, defaultValue ...int64) (int64, error) {
+	return r.registry.GetBytes(path, defaultValue...)
+}
+
+// GetDuration retrieves a time.Duration value from the underlying registry.
+func (r *readOnlyRegistry) GetDuration(path string, defaultValue ...time.Duration) (time.Duration, error) {
+	return r.registry.GetDuration(path, defaultValue...)
+}
+
+// GetStringArray retrieves a string array from the underlying registry.
+func (r *readOnlyRegistry) GetStringArray(path string, defaultValue ...[]string) ([]string, error) {
+	return r.registry.GetStringArray(path, defaultValue...)
+}
+
+// GetStringArraySep retrieves a string array from the underlying registry, splitting on sep.
+func (r *readOnlyRegistry) GetStringArraySep(path, sep string, defaultValue ...[]string) ([]string, error) {
+	return r.registry.GetStringArraySep(path, sep, defaultValue...)
+}
+
+// GetStringArrayOrDefault retrieves a string array from the underlying registry,
+// returning defaultValue for blank strings.
+func (r *readOnlyRegistry) GetStringArrayOrDefault(path string, defaultValue []string) ([]string, error) {
+	return r.registry.GetStringArrayOrDefault(path, defaultValue)
+}
+
+// GetIntArray retrieves an integer array from the underlying registry.
+func (r *readOnlyRegistry) GetIntArray(path string, defaultValue ...[]int) ([]int, error) {
+	return r.registry.GetIntArray(path, defaultValue...)
+}
+
+// GetFloatArray retrieves a float64 array from the underlying registry.
+func (r *readOnlyRegistry) GetFloatArray(path string, defaultValue ...[]float64) ([]float64, error) {
+	return r.registry.GetFloatArray(path, defaultValue...)
+}
+
+// GetMapArray retrieves an array of maps from the underlying registry.
+func (r *readOnlyRegistry) GetMapArray(path string, defaultValue ...[]map[string]interface{}) ([]map[string]interface{}, error) {
+	return r.registry.GetMapArray(path, defaultValue...)
+}
+
+// MustGetString retrieves a string value from the underlying registry, panicking on error.
+func (r *readOnlyRegistry) MustGetString(path string) string {
+	return r.registry.MustGetString(path)
+}
+
+// MustGetInt retrieves an integer value from the underlying registry, panicking on error.
+func (r *readOnlyRegistry) MustGetInt(path string) int {
+	return r.registry.MustGetInt(path)
+}
+
+// MustGetBool retrieves a boolean value from the underlying registry, panicking on error.
+func (r *readOnlyRegistry) MustGetBool(path string) bool {
+	return r.registry.MustGetBool(path)
+}
+
+// MustGetFloat retrieves a float64 value from the underlying registry, panicking on error.
+func (r *readOnlyRegistry) MustGetFloat(path string) float64 {
+	return r.registry.MustGetFloat(path)
+}
+
+// MustGetStringArray retrieves a string array from the underlying registry, panicking on error.
+func (r *readOnlyRegistry) MustGetStringArray(path string) []string {
+	return r.registry.MustGetStringArray(path)
+}
+
+// Set is rejected with ErrReadOnly.
+func (r *readOnlyRegistry) Set(path string, value interface{}) error {
+	return readOnlyError(path)
+}
+
+// SetMany is rejected with ErrReadOnly.
+func (r *readOnlyRegistry) SetMany(values map[string]interface{}) error {
+	return readOnlyError("*")
+}
+
+// GetOrSet returns the existing value, and is rejected with ErrReadOnly if it would set one.
+func (r *readOnlyRegistry) GetOrSet(path string, value interface{}) (interface{}, error) {
+	if existing, err := r.registry.Get(path); err == nil {
+		return existing, nil
+	}
+	return nil, readOnlyError(path)
+}
+
+// Unset is rejected with ErrReadOnly.
+func (r *readOnlyRegistry) Unset(path string) error {
+	return readOnlyError(path)
+}
+
+// SetString is rejected with ErrReadOnly.
+func (r *readOnlyRegistry) SetString(path string, value string) error {
+	return readOnlyError(path)
+}
+
+// SetInt is rejected with ErrReadOnly.
+func (r *readOnlyRegistry) SetInt(path string, value int) error {
+	return readOnlyError(path)
+}
+
+// SetBool is rejected with ErrReadOnly.
+func (r *readOnlyRegistry) SetBool(path string, value bool) error {
+	return readOnlyError(path)
+}
+
+// SetFloat is rejected with ErrReadOnly.
+func (r *readOnlyRegistry) SetFloat(path string, value float64) error {
+	return readOnlyError(path)
+}
+
+// AttachSchema is ignored.
+func (r *readOnlyRegistry) AttachSchema(schema configContracts.ConfigSchema) {}
+
+// SetWeaklyTypedInput is ignored.
+func (r *readOnlyRegistry) SetWeaklyTypedInput(weak bool) {}
+
+// EnforceType is ignored.
+func (r *readOnlyRegistry) EnforceType(path string, kind reflect.Kind) {}
+
+// SetFallback is rejected with ErrReadOnly.
+func (r *readOnlyRegistry) SetFallback(path, fallbackPath string) error {
+	return readOnlyError(path)
+}
+
+// Seal is ignored.
+func (r *readOnlyRegistry) Seal() {}
+
+// IsSealed reports whether the underlying registry is sealed.
+func (r *readOnlyRegistry) IsSealed() bool {
+	return r.registry.IsSealed()
+}
+
+// RegisterTemplateSection is rejected with ErrReadOnly.
+func (r *readOnlyRegistry) RegisterTemplateSection(name string) error {
+	return readOnlyError(name)
+}
+
+// RegisterDecodeHook is ignored.
+func (r *readOnlyRegistry) RegisterDecodeHook(hook configContracts.DecodeHook) {}
+
+// RegisterSchema is ignored.
+func (r *readOnlyRegistry) RegisterSchema(section string, schema configContracts.ConfigSchema) {
+}
+
+// SetMetricsObserver is ignored.
+func (r *readOnlyRegistry) SetMetricsObserver(obs configContracts.MetricsObserver) {}
+
+// EnableValueCache is ignored.
+func (r *readOnlyRegistry) EnableValueCache(enabled bool) {}
+
+// SetLogger is ignored.
+func (r *readOnlyRegistry) SetLogger(logger *slog.Logger) {}
+
+// SetArraySeparator is ignored.
+func (r *readOnlyRegistry) SetArraySeparator(sep string) {}
+
+// SetArrayOmitEmpty is ignored.
+func (r *readOnlyRegistry) SetArrayOmitEmpty(omit bool) {}
+
+// BindFlag is ignored.
+func (r *readOnlyRegistry) BindFlag(path string, f *flag.Flag) {}
+
+// ReadOnly returns the view itself.
+func (r *readOnlyRegistry) ReadOnly() configContracts.ConfigRegistry {
+	return r
+}
+
+// MergeFrom is ignored.
+func (r *readOnlyRegistry) MergeFrom(other map[string]map[string]interface{}) {}
+
+// Snapshot captures the configuration of the underlying registry.
+func (r *readOnlyRegistry) Snapshot() configContracts.Snapshot {
+	return r.registry.Snapshot()
+}
+
+// Clone returns an independent, writable copy of the underlying registry.
+// Writes to the copy never reach the original.
+func (r *readOnlyRegistry) Clone() configContracts.ConfigRegistry {
+	return r.registry.Clone()
+}
+
+// Restore is rejected with ErrReadOnly.
+func (r *readOnlyRegistry) Restore(s configContracts.Snapshot) error {
+	return readOnlyError("snapshot")
+}
+
+// Transaction is rejected with ErrReadOnly without running fn.
+func (r *readOnlyRegistry) Transaction(fn func(tx configContracts.ConfigRegistry) error) error {
+	return readOnlyError("transaction")
+}
+
+// Register is ignored.
+func (r *readOnlyRegistry) Register(name string, loader configContracts.ConfigLoader) {}
+
+// RegisterEnvPrefix is ignored.
+func (r *readOnlyRegistry) RegisterEnvPrefix(name, prefix string) {}
+
+// AddLayer is ignored.
+func (r *readOnlyRegistry) AddLayer(section string, priority int, loader configContracts.ConfigLoader) {
+}
+
+// Source reports the layer that provides a value of the underlying registry.
+func (r *readOnlyRegistry) Source(path string) (int, bool) {
+	return r.registry.Source(path)
+}
+
+// Origin reports where a value of the underlying registry came from.
+func (r *readOnlyRegistry) Origin(path string) (string, error) {
+	return r.registry.Origin(path)
+}
+
+// Resolve returns a value of the underlying registry along with its origin.
+func (r *readOnlyRegistry) Resolve(path string) (interface{}, string, error) {
+	return r.registry.Resolve(path)
+}
+
+// EnableEnvOverride is ignored.
+func (r *readOnlyRegistry) EnableEnvOverride(prefix string) {}
+
+// SetReloadEnvOnRefresh is ignored.
+func (r *readOnlyRegistry) SetReloadEnvOnRefresh(reload bool) {}
+
+// RegisterMap is ignored.
+func (r *readOnlyRegistry) RegisterMap(name string, data map[string]interface{}) {}
+
+// RegisterReader is rejected with ErrReadOnly.
+func (r *readOnlyRegistry) RegisterReader(name, format string, reader io.Reader) error {
+	return readOnlyError(name)
+}
+
+// LoadDir is rejected with ErrReadOnly.
+func (r *readOnlyRegistry) LoadDir(dir, format string) error {
+	return readOnlyError(dir)
+}
+
+// RegisterE is rejected with ErrReadOnly.
+func (r *readOnlyRegistry) RegisterE(name string, loader configContracts.ConfigLoaderE) error {
+	return readOnlyError(name)
+}
+
+// Refresh is rejected with ErrReadOnly.
+func (r *readOnlyRegistry) Refresh() error {
+	return readOnlyError("*")
+}
+
+// RefreshSection is rejected with ErrReadOnly.
+func (r *readOnlyRegistry) RefreshSection(name string) error {
+	return readOnlyError(name)
+}
+
+// StartPolling is ignored.
+func (r *readOnlyRegistry) StartPolling(interval time.Duration) {}
+
+// StopPolling is ignored.
+func (r *readOnlyRegistry) StopPolling() {}
+
+// Unmarshal deserializes a section of the underlying registry into a struct.
+func (r *readOnlyRegistry) Unmarshal(section string, v interface{}) error {
+	return r.registry.Unmarshal(section, v)
+}
+
+// UnmarshalKey deserializes a key of the underlying registry into a struct.
+func (r *readOnlyRegistry) UnmarshalKey(path string, v interface{}) error {
+	return r.registry.UnmarshalKey(path, v)
+}
+
+// UnmarshalStrict deserializes a section of the underlying registry into a struct,
+// rejecting keys no field uses.
+func (r *readOnlyRegistry) UnmarshalStrict(section string, v interface{}) error {
+	return r.registry.UnmarshalStrict(section, v)
+}
+
+// Marshal is rejected with ErrReadOnly.
+func (r *readOnlyRegistry) Marshal(section string, v interface{}) error {
+	return readOnlyError(section)
+}
+
+// Bind keeps a struct in sync with a section of the underlying registry.
+// Binding only reads configuration, so it is allowed on a read-only view.
+func (r *readOnlyRegistry) Bind(section string, v interface{}) error {
+	return r.registry.Bind(section, v)
+}
+
+// GetEnvString retrieves a string value from environment variables.
+func (r *readOnlyRegistry) GetEnvString(key string, defaultValue string) string {
+	return r.registry.GetEnvString(key, defaultValue)
+}
+
+// GetEnvInt retrieves an integer value from environment variables.
+func (r *readOnlyRegistry) GetEnvInt(key string, defaultValue int) int {
+	return r.registry.GetEnvInt(key, defaultValue)
+}
+
+// GetEnvFloat retrieves a float64 value from environment variables.
+func (r *readOnlyRegistry) GetEnvFloat(key string, defaultValue float64) float64 {
+	return r.registry.GetEnvFloat(key, defaultValue)
+}
+
+// GetEnvDuration retrieves a time.Duration value from environment variables.
+func (r *readOnlyRegistry) GetEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	return r.registry.GetEnvDuration(key, defaultValue)
+}
+
+// GetEnvBool retrieves a boolean value from environment variables.
+func (r *readOnlyRegistry) GetEnvBool(key string, defaultValue bool) bool {
+	return r.registry.GetEnvBool(key, defaultValue)
+}
+
+// GetEnvStringArray retrieves a string array from environment variables.
+func (r *readOnlyRegistry) GetEnvStringArray(key string, defaultValue []string) []string {
+	return r.registry.GetEnvStringArray(key, defaultValue)
+}
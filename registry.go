@@ -1,15 +1,20 @@
 package gonfig
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"math"
 	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	configContracts "github.com/centraunit/gonfig/contracts"
-	"github.com/joho/godotenv"
 )
 
 var (
@@ -17,44 +22,75 @@ var (
 	globalConfigRegistryOnce sync.Once
 )
 
+// EnvKey is the environment variable GetConfigRegistry reads the environment name
+// from when it is called with an empty env.
+var EnvKey = "APP_ENV"
+
 // ConfigRegistry provides a thread-safe registry for managing configuration values.
 // It supports dot notation access, type conversion, and dynamic reloading of configurations.
 type ConfigRegistry struct {
-	configs map[string]map[string]interface{}
-	loaders map[string]configContracts.ConfigLoader
-	mu      sync.RWMutex
+	configs   map[string]map[string]interface{}
+	loaders   map[string]configContracts.ConfigLoaderE
+	pathCache *PathCache
+	schema    configContracts.ConfigSchema
+	schemas   map[string]configContracts.ConfigSchema
+	bindings  map[string]func() (interface{}, bool)
+	bound     map[string][]interface{}
+	hooks     []configContracts.DecodeHook
+	enforced  map[string]reflect.Kind
+	fallbacks map[string]string
+	layers    map[string][]layer
+	layerSeq  int
+	origins   map[string]map[string]string
+	mu        sync.RWMutex
+	cache     valueCache
+
+	// List parsing for string values read by GetStringArray
+	arraySeparator string
+	arrayOmitEmpty bool
+
+	// Expand environment variable references in loaded string values
+	interpolateEnv bool
+
+	// Env files loaded when the registry was created, read again on Refresh if reloadEnv is set
+	envFiles  []string
+	reloadEnv bool
+
+	// Directories registered with LoadDir, scanned for new files on Refresh
+	dirs []configDir
+
+	// Sections whose string values are rendered as templates when loaded
+	templates map[string]bool
+
+	// Reject changes to configuration values, set by Seal
+	sealed bool
+
+	// Reject Unmarshal conversions between unrelated kinds, such as "1" to bool
+	strictTypes bool
+
+	// Prefix of the environment variables that override looked up paths, empty when disabled
+	envOverridePrefix string
+
+	// Optional logger and metrics observer, nil when disabled
+	logger  *slog.Logger
+	metrics configContracts.MetricsObserver
+
+	// Polling state, guarded separately so Refresh can run while it is held
+	pollStop chan struct{}
+	pollDone chan struct{}
+	pollMu   sync.Mutex
 }
 
-// GetConfigRegistry creates a new instance of ConfigRegistry.
+// GetConfigRegistry returns the shared ConfigRegistry, creating it on the first call.
 // It initializes the internal maps for storing configurations and their loaders.
+// An empty env falls back to the environment variable named by EnvKey; the explicit
+// argument always takes precedence. Any environment name is accepted and its variables
+// are loaded from ".env.<env>", or from ".env" if that file doesn't exist.
+// The env argument is ignored once the shared registry exists.
 func GetConfigRegistry(env string) (configContracts.ConfigRegistry, error) {
 	var initErr error
 	globalConfigRegistryOnce.Do(func() {
-		if env == "" {
-			initErr = fmt.Errorf("env is required when initializing config registry")
-			return
-		}
-
-		// Load appropriate env file
-		if env == "development" || env == "staging" || env == "production" {
-			if err := godotenv.Load(".env"); err != nil {
-				initErr = fmt.Errorf("error loading .env file: %w", err)
-				return
-			}
-		} else if env == "testing" {
-			if err := godotenv.Load(".env.testing"); err != nil {
-				initErr = fmt.Errorf("error loading .env.testing file: %w", err)
-				return
-			}
-		} else {
-			initErr = fmt.Errorf("invalid env: %s", env)
-			return
-		}
-
-		globalConfigRegistry = &ConfigRegistry{
-			configs: make(map[string]map[string]interface{}),
-			loaders: make(map[string]configContracts.ConfigLoader),
-		}
+		globalConfigRegistry, initErr = newConfigRegistry(env)
 	})
 
 	if initErr != nil {
@@ -64,48 +100,371 @@ func GetConfigRegistry(env string) (configContracts.ConfigRegistry, error) {
 	return globalConfigRegistry, nil
 }
 
+// NewConfigRegistry creates a new, independent ConfigRegistry.
+// Unlike GetConfigRegistry it returns a fresh instance on every call, so several
+// registries can hold different configurations in one process. The env argument and
+// the .env files are handled as in GetConfigRegistry.
+// Example: tenant, err := NewConfigRegistry("production")
+func NewConfigRegistry(env string, opts ...Option) (configContracts.ConfigRegistry, error) {
+	return newConfigRegistry(env, opts...)
+}
+
+// newConfigRegistry resolves the environment, loads its .env file and builds an empty registry.
+func newConfigRegistry(env string, opts ...Option) (*ConfigRegistry, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if env == "" {
+		env = os.Getenv(EnvKey)
+	}
+	if env == "" {
+		return nil, fmt.Errorf("env is required when initializing config registry: pass it or set %s", EnvKey)
+	}
+
+	if !validEnvName(env) {
+		return nil, fmt.Errorf("invalid env: %s", env)
+	}
+
+	var envFiles []string
+	if o.envFilesSet {
+		if len(o.envFiles) > 0 {
+			if err := loadDotenv(o.envFiles...); err != nil {
+				return nil, fmt.Errorf("error loading env files: %w", err)
+			}
+		}
+		envFiles = o.envFiles
+	} else if name, err := loadEnvFile(env); err != nil {
+		// Load .env.<env>, falling back to .env. A missing file isn't an error, since
+		// deployments may provide all configuration through real environment variables.
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		if o.logger != nil {
+			o.logger.Warn("env file not found", "env", env, "error", err)
+		}
+	} else {
+		envFiles = []string{name}
+	}
+
+	separator := o.arraySeparator
+	if separator == "" {
+		separator = ","
+	}
+
+	return &ConfigRegistry{
+		configs:   make(map[string]map[string]interface{}),
+		loaders:   make(map[string]configContracts.ConfigLoaderE),
+		pathCache: NewPathCache(),
+		bindings:  make(map[string]func() (interface{}, bool)),
+		bound:     make(map[string][]interface{}),
+
+		envFiles:       envFiles,
+		arraySeparator: separator,
+		interpolateEnv: o.interpolateEnv,
+		logger:         o.logger,
+	}, nil
+}
+
 // Register adds a new configuration section with its loader function.
 // The loader function will be called immediately to populate the initial configuration,
-// and can be called again during Refresh operations.
+// and can be called again during Refresh operations. Loaders run without holding the
+// lock, so they can read other sections through the registry they are passed.
+// If the loader panics the section is left empty; use RegisterE to observe the failure.
 func (r *ConfigRegistry) Register(name string, loader configContracts.ConfigLoader) {
+	_ = r.RegisterE(name, func(registry configContracts.ConfigRegistry) (map[string]interface{}, error) {
+		return loader(registry), nil
+	})
+}
+
+// RegisterE adds a new configuration section with a loader that can report failure.
+// The loader is called immediately, like with Register, but an error it returns, or a
+// panic, is returned to the caller and leaves the section empty.
+// Registering a loader for a section built with AddLayer discards its layers.
+// Section names containing a dot are rejected with ErrInvalidPath, since dots separate
+// the segments of a path; Register and RegisterMap ignore such names and log a warning.
+func (r *ConfigRegistry) RegisterE(name string, loader configContracts.ConfigLoaderE) error {
+	r.mu.Lock()
+	err := r.checkSealed(name)
+	if err == nil {
+		if err = checkSectionName(name); err != nil {
+			r.logRegister(name, err)
+		}
+	}
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// The loader runs without the lock, so it can read other sections
+	config, err := r.runLoader(loader)
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if err := r.checkSealed(name); err != nil {
+		return err
+	}
+	delete(r.layers, name)
+	return r.install(name, loader, config, err)
+}
+
+// checkSectionName rejects section names containing the path separator,
+// which couldn't be told apart from a path into a section.
+func checkSectionName(name string) error {
+	if strings.Contains(name, ".") {
+		return newPathError(ErrInvalidPath, name, name, "invalid section name '%s': section names cannot contain '.'", name)
+	}
+	return nil
+}
+
+// register sets the loader of a section and populates the section with it.
+// The loader runs with the lock held, so it must not use the registry.
+// The caller must hold the write lock.
+func (r *ConfigRegistry) register(name string, loader configContracts.ConfigLoaderE) error {
+	config, err := r.runLoader(loader)
+	return r.install(name, loader, config, err)
+}
+
+// install sets the loader of a section and populates the section with the result of
+// running it, leaving the section empty if the loader failed.
+// The caller must hold the write lock.
+func (r *ConfigRegistry) install(name string, loader configContracts.ConfigLoaderE, config map[string]interface{}, err error) error {
 	r.loaders[name] = loader
+	r.resetOrigins(name, "")
+
+	if err == nil {
+		config, err = r.load(name, config)
+	}
+	if err != nil {
+		r.store(name, make(map[string]interface{}))
+		err = loaderError(name, err)
+	} else {
+		r.store(name, config)
+	}
+	r.logRegister(name, err)
+	return err
+}
+
+// loaderPanic is the error runLoader reports for a loader that panicked.
+type loaderPanic struct {
+	value interface{}
+}
 
-	// Recover from panics in loader
+func (p loaderPanic) Error() string {
+	return fmt.Sprint(p.value)
+}
+
+// runLoader calls a loader, recovering a panic as a loaderPanic error. Loaders are
+// passed the registry, so unless a loader is known not to use it, the caller must
+// not hold the lock.
+func (r *ConfigRegistry) runLoader(loader configContracts.ConfigLoaderE) (config map[string]interface{}, err error) {
 	defer func() {
 		if rec := recover(); rec != nil {
-			r.configs[name] = make(map[string]interface{})
+			config, err = nil, loaderPanic{value: rec}
 		}
 	}()
+	return loader(r)
+}
 
-	r.configs[name] = loader(r)
+// loaderError describes the failure of the loader of a section.
+func loaderError(name string, err error) error {
+	var p loaderPanic
+	if errors.As(err, &p) {
+		return fmt.Errorf("loader for section '%s' panicked: %v", name, p.value)
+	}
+	return fmt.Errorf("loader for section '%s' failed: %w", name, err)
+}
+
+// RegisterMap registers a section with fixed contents, as Register would with a loader
+// returning data. The map is copied on registration, so later changes to it are not
+// picked up, and Refresh resets the section to the registered contents.
+// Example: RegisterMap("app", map[string]interface{}{"name": "MyApp"})
+func (r *ConfigRegistry) RegisterMap(name string, data map[string]interface{}) {
+	_ = r.RegisterE(name, mapLoader(data))
+}
+
+// mapLoader returns a loader that always returns a copy of data taken now.
+func mapLoader(data map[string]interface{}) configContracts.ConfigLoaderE {
+	config, _ := deepCopy(data).(map[string]interface{})
+	if config == nil {
+		config = make(map[string]interface{})
+	}
+	return func(registry configContracts.ConfigRegistry) (map[string]interface{}, error) {
+		return config, nil
+	}
 }
 
 // Refresh reloads all configurations using their registered loader functions.
 // This is useful when configuration sources (like environment variables) have changed.
-func (r *ConfigRegistry) Refresh() {
+// Structs bound with Bind are re-populated from the reloaded sections.
+// Sections whose loader fails keep their previous configuration, and the failures
+// are returned joined into a single error once every section has been attempted.
+// Sections are reloaded in sorted order, so errors and logs are reported reproducibly,
+// except that template sections are reloaded last so they render the fresh values.
+// Directories loaded with LoadDir are scanned first, registering files added since,
+// and env files are read again first if SetReloadEnvOnRefresh is enabled.
+func (r *ConfigRegistry) Refresh() error {
+	r.mu.Lock()
+	if err := r.checkSealed("*"); err != nil {
+		r.mu.Unlock()
+		return err
+	}
+	start := time.Now()
+	names := sortedKeys(r.loaders)
+	sort.SliceStable(names, func(i, j int) bool {
+		return !r.templates[names[i]] && r.templates[names[j]]
+	})
+
+	var errs []error
+	if r.reloadEnv && len(r.envFiles) > 0 {
+		if err := reloadDotenv(r.envFiles...); err != nil {
+			errs = append(errs, fmt.Errorf("error reloading env files: %w", err))
+		}
+		// Environment overrides read the variables at lookup time
+		r.invalidateAll()
+	}
+
+	// Sections of new files are loaded as they are registered, so they aren't in names
+	if err := r.rescanDirs(); err != nil {
+		errs = append(errs, err)
+	}
+	r.mu.Unlock()
+
+	for _, name := range names {
+		if err := r.refresh(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	duration := time.Since(start)
+	if r.metrics != nil {
+		r.metrics.ObserveRefresh(duration)
+	}
+	r.logRefresh(len(r.loaders), len(errs), duration)
+	return errors.Join(errs...)
+}
+
+// RequireSections checks that every named section is present in the registry, so
+// startup can fail fast when a loader was never registered, for example because
+// the package registering it wasn't imported. The error names every missing section.
+// Example: RequireSections("app", "database")
+func (r *ConfigRegistry) RequireSections(names ...string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var errs []error
+	for _, name := range names {
+		if _, ok := r.configs[name]; !ok {
+			errs = append(errs, newPathError(ErrSectionNotFound, name, name, "required config section not registered: '%s'", name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RefreshSection reloads a single configuration section using its registered loader.
+// Structs bound to the section with Bind are re-populated. Returns an error if the
+// section has no loader or the loader fails, in which case the previous configuration
+// of the section is kept.
+func (r *ConfigRegistry) RefreshSection(name string) error {
+	r.mu.Lock()
+	err := r.checkSealed(name)
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return r.refresh(name)
+}
+
+// refresh reloads a section with its loader and re-populates the structs bound to it.
+// The loader runs without the lock, so it can use the registry, and its result is
+// stored under the lock once it returns.
+// The caller must not hold the lock.
+func (r *ConfigRegistry) refresh(name string) error {
+	r.mu.RLock()
+	loader, ok := r.loaders[name]
+	r.mu.RUnlock()
+	if !ok {
+		return newPathError(ErrSectionNotFound, name, name, "config section not registered: '%s'", name)
+	}
+
+	config, err := r.runLoader(loader)
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	for name, loader := range r.loaders {
-		// Recover from panics for each loader
-		func() {
-			defer func() {
-				if rec := recover(); rec != nil {
-					if _, exists := r.configs[name]; !exists {
-						r.configs[name] = make(map[string]interface{})
-					}
-				}
-			}()
-			r.configs[name] = loader(r)
-		}()
+	if err := r.checkSealed(name); err != nil {
+		return err
+	}
+	err = r.update(name, config, err)
+	if err == nil {
+		err = r.rebind(name)
+	}
+	r.logReload(name, err)
+	return err
+}
+
+// update stores the result of running the loader of a section. The previous
+// configuration is kept if the loader failed or its result fails the schema
+// registered for the section; a section that panicked on its first load is left empty.
+// The caller must hold the write lock.
+func (r *ConfigRegistry) update(name string, config map[string]interface{}, err error) error {
+	if err != nil {
+		if _, exists := r.configs[name]; !exists && errors.As(err, new(loaderPanic)) {
+			r.store(name, make(map[string]interface{}))
+		}
+		return loaderError(name, err)
 	}
 
+	origins := r.origins[name]
+	config, err = r.load(name, config)
+	if err != nil {
+		return loaderError(name, err)
+	}
+	if schema, ok := r.schemas[name]; ok {
+		if err := schema.Validate(map[string]interface{}{name: config}); err != nil {
+			delete(r.origins, name)
+			if origins != nil {
+				r.origins[name] = origins
+			}
+			return fmt.Errorf("config section '%s' failed validation: %w", name, err)
+		}
+	}
+	r.store(name, config)
+	return nil
+}
+
+// load prepares the result of a loader: if enabled, environment variables are expanded
+// in it, then its templates are rendered if it is a template section.
+// On success the origins recorded for the section are reset to the loader, except
+// for interpolated values, which are marked as coming from the environment.
+// The caller must hold the write lock.
+func (r *ConfigRegistry) load(name string, config map[string]interface{}) (map[string]interface{}, error) {
+	r.resetOrigins(name, "")
+	if r.interpolateEnv {
+		config = expandEnv(config, "", func(path string) {
+			r.recordOrigin(name, path, originEnv)
+		}).(map[string]interface{})
+	}
+	if r.templates[name] {
+		return r.renderTemplates(name, config)
+	}
+	return config, nil
+}
+
+// store replaces the configuration of a section and drops cached values read from it.
+// The caller must hold the write lock.
+func (r *ConfigRegistry) store(section string, config map[string]interface{}) {
+	r.configs[section] = config
+	r.invalidate(section)
 }
 
 // Get retrieves a value from the configuration using dot notation.
+// Maps and slices are returned as deep copies, so callers may modify them freely.
 // Returns an error if the path is invalid or the value doesn't exist.
 // Example: Get("database.connections.mysql.host")
 func (r *ConfigRegistry) Get(path string) (interface{}, error) {
@@ -114,30 +473,209 @@ func (r *ConfigRegistry) Get(path string) (interface{}, error) {
 
 	// Normal lookup
 	value, err := r.lookup(path)
+	if r.metrics != nil {
+		r.metrics.ObserveGet(path, err == nil)
+	}
 	if err != nil {
+		r.logMiss(path, err)
 		return nil, err
 	}
 
-	return value, nil
+	return deepCopy(value), nil
+}
+
+// GetContext retrieves a value from the configuration using dot notation,
+// honoring cancellation of the given context.
+// Returns the context's error if it is already done before the lookup starts.
+// Example: GetContext(ctx, "database.connections.mysql.host")
+func (r *ConfigRegistry) GetContext(ctx context.Context, path string) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return r.Get(path)
+}
+
+// GetMany retrieves several values under a single read lock.
+// Paths that resolve are returned in the map; the others are left out and their
+// errors are joined into the returned error, so one missing path doesn't fail the batch.
+// Example: GetMany([]string{"app.name", "app.port"})
+func (r *ConfigRegistry) GetMany(paths []string) (map[string]interface{}, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	values := make(map[string]interface{}, len(paths))
+	var errs []error
+	for _, path := range paths {
+		value, err := r.lookup(path)
+		if r.metrics != nil {
+			r.metrics.ObserveGet(path, err == nil)
+		}
+		if err != nil {
+			r.logMiss(path, err)
+			errs = append(errs, err)
+			continue
+		}
+		values[path] = deepCopy(value)
+	}
+
+	return values, errors.Join(errs...)
+}
+
+// GetKeys returns the sorted keys of the map at path, for iterating over
+// dynamically named configuration blocks.
+// Returns an error if the path doesn't exist or its value isn't a map.
+// Example: GetKeys("database.connections") returns ["mysql", "pgsql"]
+func (r *ConfigRegistry) GetKeys(path string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	value, err := r.lookup(path)
+	if err != nil {
+		return nil, err
+	}
+
+	node, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, newTypeError(path, "map[string]interface {}", value, "value at '%s' is not a map", path)
+	}
+	return sortedKeys(node), nil
+}
+
+// ForEach calls fn with every top-level key of a section and a copy of its value,
+// in sorted key order, stopping at and returning the first error fn returns.
+// The read lock is held for the whole iteration, so the section can't change part way
+// through; fn must not call back into the registry, which could deadlock.
+// Example: ForEach("plugins", func(name string, cfg interface{}) error { ... })
+func (r *ConfigRegistry) ForEach(section string, fn func(key string, value interface{}) error) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	config, ok := r.configs[section]
+	if !ok {
+		return newPathError(ErrSectionNotFound, section, section, "config section not found: '%s'", section)
+	}
+	if config == nil {
+		return newPathError(ErrNilSection, section, section, "config section is nil: '%s'", section)
+	}
+	for _, key := range sortedKeys(config) {
+		if err := fn(key, deepCopy(config[key])); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // lookup performs the actual configuration lookup
+// Path parts are shared with the path cache and must not be modified.
 func (r *ConfigRegistry) lookup(path string) (interface{}, error) {
-	parts := strings.Split(path, ".")
+	value, _, err := r.lookupSource(path)
+	return value, err
+}
+
+// valueSource identifies which level of the lookup precedence provided a value.
+type valueSource int
+
+const (
+	sourceStored valueSource = iota
+	sourceFlag
+	sourceEnv
+	sourceFallback
+)
+
+// lookupSource looks up path like lookup and also reports where the value came from.
+// Flags that were set win, then environment overrides, unless the stored value was
+// written with Set, then stored values, then the value of the path's fallback.
+// The caller must hold the read or write lock.
+func (r *ConfigRegistry) lookupSource(path string) (interface{}, valueSource, error) {
+	if binding, ok := r.bindings[path]; ok {
+		if value, set := binding(); set {
+			return value, sourceFlag, nil
+		}
+	}
+
+	parts := r.pathCache.shared(path)
+	override, overridden := r.envOverride(parts)
+	if overridden && !r.explicitlySet(parts) {
+		return override, sourceEnv, nil
+	}
 
+	value, err := r.stored(path, parts)
+	if err != nil && overridden {
+		// Set wrote a parent of the path that doesn't contain it
+		return override, sourceEnv, nil
+	}
+	if err != nil {
+		if value, ok := r.fallback(parts, err); ok {
+			return value, sourceFallback, nil
+		}
+	}
+	return value, sourceStored, err
+}
+
+// stored returns the value stored at path, which has been split into parts.
+// The caller must hold the read or write lock.
+func (r *ConfigRegistry) stored(path string, parts []string) (interface{}, error) {
 	section := parts[0]
 	config, ok := r.configs[section]
 	if !ok {
-		return nil, fmt.Errorf("config section not found: '%s' in path '%s'", section, path)
+		return nil, newPathError(ErrSectionNotFound, path, section, "config section not found: '%s' in path '%s'", section, path)
 	}
 
 	if config == nil {
-		return nil, fmt.Errorf("config section is nil: '%s' in path '%s'", section, path)
+		return nil, newPathError(ErrNilSection, path, section, "config section is nil: '%s' in path '%s'", section, path)
 	}
 	if len(parts) == 1 {
 		return config, nil
 	}
-	return traverse(config, parts[1:], path)
+	value, err := traverse(config, parts[1:], path)
+	if err == nil && len(r.enforced) > 0 {
+		err = r.checkEnforced(path, parts, value)
+	}
+	return value, err
+}
+
+// EnforceType declares the kind the value at path must always have. Every accessor
+// reading the path, including Get, returns a type conversion error if the stored value
+// has another kind, instead of converting it, which surfaces loader bugs early. Typed
+// accessors return the error even when passed a default.
+// Passing reflect.Invalid removes the enforcement.
+// Example: EnforceType("database.port", reflect.Int)
+func (r *ConfigRegistry) EnforceType(path string, kind reflect.Kind) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.checkSealed(path) != nil {
+		return
+	}
+	// The map is replaced rather than modified, since transactions share it
+	enforced := make(map[string]reflect.Kind, len(r.enforced)+1)
+	for p, k := range r.enforced {
+		enforced[p] = k
+	}
+	path = joinParts(splitPath(path))
+	if kind == reflect.Invalid {
+		delete(enforced, path)
+	} else {
+		enforced[path] = kind
+	}
+	r.enforced = enforced
+	r.invalidateAll()
+}
+
+// checkEnforced returns an error if a kind is enforced for path and value doesn't have it.
+// The caller must hold the read or write lock.
+func (r *ConfigRegistry) checkEnforced(path string, parts []string, value interface{}) error {
+	kind, ok := r.enforced[path]
+	if !ok {
+		if kind, ok = r.enforced[joinParts(parts)]; !ok {
+			return nil
+		}
+	}
+	if actual := reflect.ValueOf(value).Kind(); actual != kind {
+		return newTypeError(path, kind.String(), value, "value at '%s' must be of kind %s: found type %T", path, kind, value)
+	}
+	return nil
 }
 
 // Set updates a configuration value using dot notation.
@@ -147,27 +685,283 @@ func (r *ConfigRegistry) Set(path string, value interface{}) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	parts := strings.Split(path, ".")
+	if err := r.checkSealed(path); err != nil {
+		return err
+	}
+	return r.set(path, value)
+}
+
+// GetOrSet returns the existing value at path, or sets and returns the given value
+// if the path doesn't exist. Both steps happen under a single write lock.
+// Other lookup errors, such as a parent that isn't a map or a value of the wrong
+// kind for EnforceType, are returned without setting anything, as are the errors
+// of Set for invalid paths.
+// Example: GetOrSet("cache.ttl", 300)
+func (r *ConfigRegistry) GetOrSet(path string, value interface{}) (interface{}, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, err := r.lookup(path)
+	if err == nil {
+		return deepCopy(existing), nil
+	}
+	if !isMissing(err) {
+		return nil, err
+	}
+	if err := r.checkSealed(path); err != nil {
+		return nil, err
+	}
+
+	if err := r.set(path, value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// SetMany updates several values under a single write lock.
+// Paths are applied in sorted order, so a parent is written before its children.
+// Either every value is applied or, if any path is invalid or fails schema
+// validation, none are and the first error is returned.
+// Example: SetMany(map[string]interface{}{"features.search": true, "features.beta": false})
+func (r *ConfigRegistry) SetMany(values map[string]interface{}) error {
+	return r.commit(setOps(values))
+}
+
+// setOps returns one set operation per value, ordered by path.
+func setOps(values map[string]interface{}) []func(r *ConfigRegistry) error {
+	paths := make([]string, 0, len(values))
+	for path := range values {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	ops := make([]func(r *ConfigRegistry) error, len(paths))
+	for i, path := range paths {
+		path, value := path, values[path]
+		ops[i] = func(r *ConfigRegistry) error {
+			return r.set(path, value)
+		}
+	}
+	return ops
+}
+
+// set performs the actual configuration update.
+// The caller must hold the write lock.
+func (r *ConfigRegistry) set(path string, value interface{}) error {
+	parts := r.pathCache.shared(path)
 	if len(parts) < 2 {
-		return fmt.Errorf("invalid config path: %s", path)
+		return newPathError(ErrInvalidPath, path, path, "invalid config path: %s", path)
 	}
 
 	section := parts[0]
 	config, ok := r.configs[section]
 	if !ok {
-		return fmt.Errorf("config section not found: %s", section)
+		return newPathError(ErrSectionNotFound, path, section, "config section not found: %s", section)
 	}
 
-	return setValue(config, parts[1:], value)
+	if r.schema != nil {
+		if err := r.schema.ValidateField(path, value); err != nil {
+			return err
+		}
+	}
+
+	// Write to a copy so readers holding values from an earlier Get never see the change
+	updated := copyPath(config, parts[1:len(parts)-1])
+	if err := setValue(updated, parts[1:], value, path); err != nil {
+		return err
+	}
+	r.store(section, updated)
+	r.recordOrigin(section, joinParts(parts[1:]), originSet)
+	return nil
+}
+
+// Unset removes a configuration value using dot notation.
+// Returns an error if the path is invalid, the section doesn't exist, or the key is not found.
+// Example: Unset("app.name")
+func (r *ConfigRegistry) Unset(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkSealed(path); err != nil {
+		return err
+	}
+	return r.unset(path)
+}
+
+// unset performs the actual removal.
+// The caller must hold the write lock.
+func (r *ConfigRegistry) unset(path string) error {
+	parts := r.pathCache.shared(path)
+	if len(parts) < 2 {
+		return newPathError(ErrInvalidPath, path, path, "invalid config path: %s", path)
+	}
+
+	section := parts[0]
+	config, ok := r.configs[section]
+	if !ok {
+		return newPathError(ErrSectionNotFound, path, section, "config section not found: %s", section)
+	}
+	if config == nil {
+		return newPathError(ErrNilSection, path, section, "config section is nil: %s", section)
+	}
+
+	updated := copyPath(config, parts[1:len(parts)-1])
+	var parent interface{} = updated
+	if len(parts) > 2 {
+		var err error
+		if parent, err = traverse(updated, parts[1:len(parts)-1], path); err != nil {
+			return err
+		}
+	}
+
+	node, ok := parent.(map[string]interface{})
+	if !ok {
+		return newPathError(ErrInvalidPath, path, path, "cannot unset '%s': parent is not a map", path)
+	}
+	key := parts[len(parts)-1]
+	if _, exists := node[key]; !exists {
+		return newPathError(ErrKeyNotFound, path, key, "key not found: '%s' in path '%s'", key, path)
+	}
+	delete(node, key)
+	r.store(section, updated)
+	r.forgetOrigin(section, joinParts(parts[1:]))
+	return nil
+}
+
+// SetString updates a string value using dot notation.
+func (r *ConfigRegistry) SetString(path string, value string) error {
+	return r.Set(path, value)
+}
+
+// SetInt updates an integer value using dot notation.
+func (r *ConfigRegistry) SetInt(path string, value int) error {
+	return r.Set(path, value)
+}
+
+// SetBool updates a boolean value using dot notation.
+func (r *ConfigRegistry) SetBool(path string, value bool) error {
+	return r.Set(path, value)
+}
+
+// SetFloat updates a float64 value using dot notation.
+func (r *ConfigRegistry) SetFloat(path string, value float64) error {
+	return r.Set(path, value)
+}
+
+// AttachSchema attaches a schema that every subsequent write is validated against.
+// Writes to paths with a schema field must pass its type check and Validator,
+// writes to other paths pass through unchanged. Passing nil detaches the schema.
+func (r *ConfigRegistry) AttachSchema(schema configContracts.ConfigSchema) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.checkSealed("*") != nil {
+		return
+	}
+	r.schema = schema
+}
+
+// RegisterSchema registers a schema that Refresh and RefreshSection validate the
+// reloaded contents of a section against. Field paths are full paths that start with
+// the section name. If validation fails the previous contents of the section are kept
+// and the failure is returned. Passing nil removes the section's schema.
+// Example: RegisterSchema("app", schema)
+func (r *ConfigRegistry) RegisterSchema(section string, schema configContracts.ConfigSchema) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.checkSealed(section) != nil {
+		return
+	}
+	if schema == nil {
+		delete(r.schemas, section)
+		return
+	}
+	if r.schemas == nil {
+		r.schemas = make(map[string]configContracts.ConfigSchema)
+	}
+	r.schemas[section] = schema
+}
+
+// SetArraySeparator sets the separator GetStringArray splits string values on.
+// An empty separator restores the default comma.
+// Example: SetArraySeparator("|") parses "a|b|c" as ["a", "b", "c"]
+func (r *ConfigRegistry) SetArraySeparator(sep string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.checkSealed("*") != nil {
+		return
+	}
+	if sep == "" {
+		sep = ","
+	}
+	r.arraySeparator = sep
+}
+
+// SetArrayOmitEmpty controls whether GetStringArray drops segments that are
+// empty after trimming when splitting string values, so "a,,b," yields ["a", "b"].
+func (r *ConfigRegistry) SetArrayOmitEmpty(omit bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.checkSealed("*") != nil {
+		return
+	}
+	r.arrayOmitEmpty = omit
+}
+
+// ReadOnly returns a view of the registry that rejects all mutations.
+// Reads through the view reflect later updates made through the registry itself.
+func (r *ConfigRegistry) ReadOnly() configContracts.ConfigRegistry {
+	return &readOnlyRegistry{registry: r}
+}
+
+// MergeFrom deep-merges the given sections into the registry.
+// Sections that don't exist are created, and existing sections are merged recursively.
+// Values from other take precedence over existing values at the same path.
+// Example: MergeFrom(map[string]map[string]interface{}{"app": {"name": "MyApp"}})
+func (r *ConfigRegistry) MergeFrom(other map[string]map[string]interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.checkSealed("*") != nil {
+		return
+	}
+	r.mergeFrom(other)
+}
+
+// mergeFrom performs the actual merge.
+// The caller must hold the write lock.
+func (r *ConfigRegistry) mergeFrom(other map[string]map[string]interface{}) {
+	for section, values := range other {
+		existing, ok := r.configs[section]
+		config := copyMap(existing)
+		mergeMaps(config, values)
+		r.store(section, config)
+		if ok {
+			r.recordLeafOrigins(section, "", values, originMerge)
+		} else {
+			r.resetOrigins(section, originMerge)
+		}
+	}
 }
 
 // GetString retrieves a string value from the configuration.
 // Accepts optional default value to be returned if the path doesn't exist.
-// Returns an error if the value cannot be converted to string.
+// Supports conversion from numbers, booleans and other scalar values, so a port
+// stored as 8080 is read as "8080".
+// Returns an error if the value is a map or slice.
 func (r *ConfigRegistry) GetString(path string, defaultValue ...string) (string, error) {
+	cached, gen, ok := r.cached("string", path)
+	if ok {
+		return cached.(string), nil
+	}
+
 	value, err := r.Get(path)
 	if err != nil {
-		if len(defaultValue) > 0 {
+		if len(defaultValue) > 0 && isMissing(err) {
+			r.logDefault(path, err)
 			return defaultValue[0], nil
 		}
 		return "", err
@@ -175,66 +969,120 @@ func (r *ConfigRegistry) GetString(path string, defaultValue ...string) (string,
 
 	str, ok := value.(string)
 	if !ok {
-		return "", fmt.Errorf("value at %s is not a string", path)
+		if kind := reflect.ValueOf(value).Kind(); kind == reflect.Map || kind == reflect.Slice || kind == reflect.Array || value == nil {
+			return "", newTypeError(path, "string", value, "value at %s is not a string", path)
+		}
+		str, _ = toString(value)
 	}
 
+	remember(r, "string", path, gen, str)
 	return str, nil
 }
 
 // GetInt retrieves an integer value from the configuration.
 // Accepts optional default value to be returned if the path doesn't exist.
-// Supports conversion from string and float64 values.
-// Returns an error if the value cannot be converted to int.
+// Supports conversion from string, int64 and float64 values. Floats must be whole numbers,
+// so 3.0 reads as 3 but 3.9 is an error rather than being truncated.
+// Returns an error if the value cannot be converted to int, including values outside
+// the platform's int range.
 func (r *ConfigRegistry) GetInt(path string, defaultValue ...int) (int, error) {
+	cached, gen, ok := r.cached("int", path)
+	if ok {
+		return cached.(int), nil
+	}
+
 	value, err := r.Get(path)
 	if err != nil {
-		if len(defaultValue) > 0 {
+		if len(defaultValue) > 0 && isMissing(err) {
+			r.logDefault(path, err)
 			return defaultValue[0], nil
 		}
 		return 0, err
 	}
 
+	result, err := convertInt(path, value)
+	if err != nil {
+		return 0, err
+	}
+
+	remember(r, "int", path, gen, result)
+	return result, nil
+}
+
+// convertInt converts the value at path to an int. Floats must be whole numbers, and
+// values outside the platform's int range are rejected rather than wrapped.
+func convertInt(path string, value interface{}) (int, error) {
 	switch v := value.(type) {
 	case int:
 		return v, nil
+	case int32:
+		return int(v), nil
+	case int64:
+		if v < math.MinInt || v > math.MaxInt {
+			return 0, newTypeError(path, "int", v, "value %v at path %s overflows int", v, path)
+		}
+		return int(v), nil
 	case float64:
+		if v != math.Trunc(v) {
+			return 0, newTypeError(path, "int", v, "value %v at path %s is not an integer", v, path)
+		}
+		// -MinInt is a power of two, so unlike MaxInt it is exact as a float64
+		if v < math.MinInt || v >= -math.MinInt {
+			return 0, newTypeError(path, "int", v, "value %v at path %s overflows int", v, path)
+		}
 		return int(v), nil
 	case string:
-		i, err := strconv.Atoi(v)
+		i, err := strconv.ParseInt(v, 10, 64)
+		if errors.Is(err, strconv.ErrRange) || err == nil && (i < math.MinInt || i > math.MaxInt) {
+			return 0, newTypeError(path, "int", v, "value %v at path %s overflows int", v, path)
+		}
 		if err != nil {
-			return 0, fmt.Errorf("cannot convert value '%v' at path '%s' to int: %v", v, path, err)
+			return 0, newTypeError(path, "int", v, "cannot convert value '%v' at path '%s' to int: %w", v, path, err)
 		}
-		return i, nil
+		return int(i), nil
 	default:
-		return 0, fmt.Errorf("cannot convert value at path '%s' to int: found type %T", path, value)
+		return 0, newTypeError(path, "int", value, "cannot convert value at path '%s' to int: found type %T", path, value)
 	}
 }
 
 // GetBool retrieves a boolean value from the configuration.
 // Accepts optional default value to be returned if the path doesn't exist.
-// Supports conversion from string values ("true"/"false").
-// Returns an error if the value cannot be converted to bool.
+// Supports conversion from string values accepted by strconv.ParseBool, such as
+// "true", "false", "1" and "0", and from int values, where any nonzero value is true,
+// as Unmarshal converts them. Returns an error if the value cannot be converted to bool.
 func (r *ConfigRegistry) GetBool(path string, defaultValue ...bool) (bool, error) {
+	cached, gen, ok := r.cached("bool", path)
+	if ok {
+		return cached.(bool), nil
+	}
+
 	value, err := r.Get(path)
 	if err != nil {
-		if len(defaultValue) > 0 {
+		if len(defaultValue) > 0 && isMissing(err) {
+			r.logDefault(path, err)
 			return defaultValue[0], nil
 		}
 		return false, err
 	}
 
+	var result bool
 	switch v := value.(type) {
 	case bool:
-		return v, nil
+		result = v
 	case string:
 		b, err := strconv.ParseBool(v)
 		if err != nil {
-			return false, fmt.Errorf("cannot convert value '%v' at path '%s' to bool: %v", v, path, err)
+			return false, newTypeError(path, "bool", v, "cannot convert value '%v' at path '%s' to bool: %w", v, path, err)
 		}
-		return b, nil
+		result = b
+	case int:
+		result = v != 0
 	default:
-		return false, fmt.Errorf("cannot convert value at path '%s' to bool: found type %T", path, value)
+		return false, newTypeError(path, "bool", value, "cannot convert value at path '%s' to bool: found type %T", path, value)
 	}
+
+	remember(r, "bool", path, gen, result)
+	return result, nil
 }
 
 // GetFloat retrieves a float64 value from the configuration.
@@ -242,68 +1090,374 @@ func (r *ConfigRegistry) GetBool(path string, defaultValue ...bool) (bool, error
 // Supports conversion from string and int values.
 // Returns an error if the value cannot be converted to float64.
 func (r *ConfigRegistry) GetFloat(path string, defaultValue ...float64) (float64, error) {
+	cached, gen, ok := r.cached("float64", path)
+	if ok {
+		return cached.(float64), nil
+	}
+
 	value, err := r.Get(path)
 	if err != nil {
-		if len(defaultValue) > 0 {
+		if len(defaultValue) > 0 && isMissing(err) {
+			r.logDefault(path, err)
 			return defaultValue[0], nil
 		}
 		return 0, err
 	}
 
+	var result float64
 	switch v := value.(type) {
 	case float64:
-		return v, nil
+		result = v
 	case int:
-		return float64(v), nil
+		result = float64(v)
 	case string:
 		f, err := strconv.ParseFloat(v, 64)
 		if err != nil {
-			return 0, fmt.Errorf("cannot convert value '%v' at path '%s' to float64: %v", v, path, err)
+			return 0, newTypeError(path, "float64", v, "cannot convert value '%v' at path '%s' to float64: %w", v, path, err)
 		}
-		return f, nil
+		result = f
 	default:
-		return 0, fmt.Errorf("cannot convert value at path '%s' to float64: found type %T", path, value)
+		return 0, newTypeError(path, "float64", value, "cannot convert value at path '%s' to float64: found type %T", path, value)
+	}
+
+	remember(r, "float64", path, gen, result)
+	return result, nil
+}
+
+// GetBytes retrieves a byte count from the configuration.
+// Accepts optional default value to be returned if the path doesn't exist.
+// Supports size strings with decimal (KB, MB, GB, TB) or binary (KiB, MiB, GiB, TiB)
+// units, such as "512KB" or "2GiB". Whole, non-negative numbers and unit-less strings
+// are taken as bytes.
+// Returns an error if the value cannot be parsed as a size.
+func (r *ConfigRegistry) GetBytes(path string, defaultValue ...int64) (int64, error) {
+	value, err := r.Get(path)
+	if err != nil {
+		if len(defaultValue) > 0 && isMissing(err) {
+			r.logDefault(path, err)
+			return defaultValue[0], nil
+		}
+		return 0, err
+	}
+
+	n, err := toBytes(value)
+	if err != nil {
+		return 0, newTypeError(path, "bytes", value, "cannot convert value '%v' at path '%s' to bytes: %w", value, path, err)
 	}
+	return n, nil
+}
+
+// GetDuration retrieves a time.Duration value from the configuration.
+// Accepts optional default value to be returned if the path doesn't exist.
+// Supports duration strings such as "30s" or "1h15m", and integers taken as nanoseconds.
+// Returns an error if the value cannot be parsed as a duration.
+func (r *ConfigRegistry) GetDuration(path string, defaultValue ...time.Duration) (time.Duration, error) {
+	value, err := r.Get(path)
+	if err != nil {
+		if len(defaultValue) > 0 && isMissing(err) {
+			r.logDefault(path, err)
+			return defaultValue[0], nil
+		}
+		return 0, err
+	}
+
+	d, err := toDuration(value)
+	if err != nil {
+		return 0, newTypeError(path, "time.Duration", value, "cannot convert value '%v' at path '%s' to time.Duration: %w", value, path, err)
+	}
+	return d, nil
 }
 
 // GetStringArray retrieves a string array from the configuration.
 // Accepts optional default value to be returned if the path doesn't exist.
-// Supports conversion from comma-separated strings and []interface{} values.
-// Returns an error if the value cannot be converted to []string.
+// Supports conversion from separated strings, []interface{} values and numeric slices
+// such as []int, whose numbers are formatted as strings. String values are split on
+// the separator set by SetArraySeparator (comma by default) and trimmed.
+// Returns an error if the value, or an element that is neither a string nor a number,
+// cannot be converted.
 func (r *ConfigRegistry) GetStringArray(path string, defaultValue ...[]string) ([]string, error) {
+	return r.getStringArray(path, "", defaultValue...)
+}
+
+// GetStringArraySep retrieves a string array like GetStringArray, but splits string
+// values on sep instead of the registry's separator. Segments are still trimmed, so
+// GetStringArraySep(path, ";") reads "a; b; c" as ["a", "b", "c"].
+// An empty sep uses the registry's separator.
+func (r *ConfigRegistry) GetStringArraySep(path, sep string, defaultValue ...[]string) ([]string, error) {
+	return r.getStringArray(path, sep, defaultValue...)
+}
+
+// GetStringArrayOrDefault retrieves a string array like GetStringArray, but returns
+// defaultValue for a string value that is empty or only whitespace, as well as for a
+// missing path, rather than an empty slice. This suits lists read from environment
+// variables that are set but blank. Other errors are returned as by GetStringArray.
+// Example: GetStringArrayOrDefault("app.allowed.hosts", []string{"localhost"})
+func (r *ConfigRegistry) GetStringArrayOrDefault(path string, defaultValue []string) ([]string, error) {
+	value, err := r.Get(path)
+	if err != nil {
+		if isMissing(err) {
+			r.logDefault(path, err)
+			return defaultValue, nil
+		}
+		return nil, err
+	}
+	if s, ok := value.(string); ok && strings.TrimSpace(s) == "" {
+		return defaultValue, nil
+	}
+	return r.toStringArray(path, "", value)
+}
+
+// getStringArray retrieves a string array, splitting string values on sep,
+// or on the registry's separator if sep is empty.
+func (r *ConfigRegistry) getStringArray(path, sep string, defaultValue ...[]string) ([]string, error) {
 	value, err := r.Get(path)
 	if err != nil {
-		if len(defaultValue) > 0 {
+		if len(defaultValue) > 0 && isMissing(err) {
+			r.logDefault(path, err)
 			return defaultValue[0], nil
 		}
 		return nil, err
 	}
+	return r.toStringArray(path, sep, value)
+}
 
+// toStringArray converts the value looked up at path to a string array, splitting
+// string values on sep, or on the registry's separator if sep is empty.
+func (r *ConfigRegistry) toStringArray(path, sep string, value interface{}) ([]string, error) {
 	switch v := value.(type) {
 	case []string:
+		// Get returns a copy, so callers can't modify the stored slice through it
 		return v, nil
 	case string:
-		if v == "" {
-			return []string{}, nil
-		}
-		parts := strings.Split(v, ",")
-		for i := range parts {
-			parts[i] = strings.TrimSpace(parts[i])
+		r.mu.RLock()
+		if sep == "" {
+			sep = r.arraySeparator
 		}
-		return parts, nil
+		omitEmpty := r.arrayOmitEmpty
+		r.mu.RUnlock()
+
+		return splitList(v, sep, omitEmpty), nil
 	case []interface{}:
 		result := make([]string, len(v))
 		for i, item := range v {
-			str, ok := item.(string)
-			if !ok {
-				return nil, fmt.Errorf("cannot convert item at index %d in path '%s' to string: found type %T", i, path, item)
+			if _, ok := item.(string); !ok {
+				if _, ok := numericValue(item); !ok {
+					return nil, newTypeError(path, "string", item, "cannot convert item at index %d in path '%s' to string: found type %T", i, path, item)
+				}
 			}
-			result[i] = str
+			result[i], _ = toString(item)
 		}
 		return result, nil
 	default:
-		return nil, fmt.Errorf("cannot convert value at path '%s' to string array: found type %T", path, value)
+		// Slices of numbers, such as []int or []float64
+		slice := reflect.ValueOf(value)
+		if slice.Kind() == reflect.Slice {
+			if _, ok := numericValue(reflect.Zero(slice.Type().Elem()).Interface()); ok {
+				result := make([]string, slice.Len())
+				for i := range result {
+					result[i], _ = toString(slice.Index(i).Interface())
+				}
+				return result, nil
+			}
+		}
+		return nil, newTypeError(path, "[]string", value, "cannot convert value at path '%s' to string array: found type %T", path, value)
+	}
+}
+
+// GetIntArray retrieves an integer array from the configuration.
+// Accepts optional default value to be returned if the path doesn't exist.
+// Supports numeric slices, []interface{} values and separated strings like "1,2,3",
+// converting each element in turn as GetInt does, so fractions and values outside the
+// int range are rejected. Returns an error naming the index of the first element that
+// cannot be converted to int.
+func (r *ConfigRegistry) GetIntArray(path string, defaultValue ...[]int) ([]int, error) {
+	value, err := r.Get(path)
+	if err != nil {
+		if len(defaultValue) > 0 && isMissing(err) {
+			r.logDefault(path, err)
+			return defaultValue[0], nil
+		}
+		return nil, err
+	}
+
+	items, err := r.listItems(path, "[]int", value)
+	if err != nil {
+		return nil, err
+	}
+
+	// Elements are converted like GetInt converts the element's own path
+	result := make([]int, len(items))
+	for i, item := range items {
+		n, err := convertInt(joinKey(path, strconv.Itoa(i)), item)
+		if err != nil {
+			return nil, newTypeError(path, "int", item, "cannot convert item at index %d in path '%s' to int: %w", i, path, err)
+		}
+		result[i] = n
+	}
+	return result, nil
+}
+
+// GetFloatArray retrieves a float64 array from the configuration.
+// Accepts optional default value to be returned if the path doesn't exist.
+// Supports numeric slices, []interface{} values and separated strings like "0.5,1.5",
+// converting each element in turn. Returns an error naming the index of the first
+// element that cannot be converted to float64.
+func (r *ConfigRegistry) GetFloatArray(path string, defaultValue ...[]float64) ([]float64, error) {
+	value, err := r.Get(path)
+	if err != nil {
+		if len(defaultValue) > 0 && isMissing(err) {
+			r.logDefault(path, err)
+			return defaultValue[0], nil
+		}
+		return nil, err
+	}
+
+	items, err := r.listItems(path, "[]float64", value)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]float64, len(items))
+	for i, item := range items {
+		f, err := toFloat64(item)
+		if err != nil {
+			return nil, newTypeError(path, "float64", item, "cannot convert item at index %d in path '%s' to float64: %w", i, path, err)
+		}
+		result[i] = f
+	}
+	return result, nil
+}
+
+// GetMapArray retrieves an array of maps from the configuration, such as a list of
+// route definitions. Accepts optional default value to be returned if the path doesn't exist.
+// Supports []map[string]interface{} and []interface{} values whose elements are maps.
+// The maps are deep copies. Returns an error naming the index of the first element
+// that isn't a map.
+func (r *ConfigRegistry) GetMapArray(path string, defaultValue ...[]map[string]interface{}) ([]map[string]interface{}, error) {
+	value, err := r.Get(path)
+	if err != nil {
+		if len(defaultValue) > 0 && isMissing(err) {
+			r.logDefault(path, err)
+			return defaultValue[0], nil
+		}
+		return nil, err
+	}
+
+	if maps, ok := value.([]map[string]interface{}); ok {
+		return maps, nil
+	}
+
+	slice := reflect.ValueOf(value)
+	if slice.Kind() != reflect.Slice {
+		return nil, newTypeError(path, "[]map[string]interface {}", value, "cannot convert value at path '%s' to []map[string]interface {}: found type %T", path, value)
+	}
+	result := make([]map[string]interface{}, slice.Len())
+	for i := range result {
+		item := slice.Index(i).Interface()
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, newTypeError(path, "map[string]interface {}", item, "item at index %d in path '%s' is not a map: found type %T", i, path, item)
+		}
+		result[i] = m
+	}
+	return result, nil
+}
+
+// listItems returns the elements of a slice value, or the segments of a string value
+// split like GetStringArray does, for element-wise conversion by the array accessors.
+func (r *ConfigRegistry) listItems(path, expected string, value interface{}) ([]interface{}, error) {
+	if str, ok := value.(string); ok {
+		r.mu.RLock()
+		sep, omitEmpty := r.arraySeparator, r.arrayOmitEmpty
+		r.mu.RUnlock()
+
+		parts := splitList(str, sep, omitEmpty)
+		items := make([]interface{}, len(parts))
+		for i, part := range parts {
+			items[i] = part
+		}
+		return items, nil
+	}
+
+	slice := reflect.ValueOf(value)
+	if slice.Kind() != reflect.Slice {
+		return nil, newTypeError(path, expected, value, "cannot convert value at path '%s' to %s: found type %T", path, expected, value)
+	}
+	items := make([]interface{}, slice.Len())
+	for i := range items {
+		items[i] = slice.Index(i).Interface()
+	}
+	return items, nil
+}
+
+// splitList splits a string on sep and trims each segment.
+// Segments that are empty after trimming are dropped when omitEmpty is set.
+func splitList(value, sep string, omitEmpty bool) []string {
+	if value == "" {
+		return []string{}
+	}
+
+	parts := strings.Split(value, sep)
+	result := parts[:0]
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if omitEmpty && part == "" {
+			continue
+		}
+		result = append(result, part)
+	}
+	return result
+}
+
+// MustGetString retrieves a string value like GetString.
+// It panics if the value is missing or cannot be converted, so it is
+// intended for startup-time reads where a missing value is fatal.
+func (r *ConfigRegistry) MustGetString(path string) string {
+	value, err := r.GetString(path)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// MustGetInt retrieves an integer value like GetInt.
+// It panics if the value is missing or cannot be converted.
+func (r *ConfigRegistry) MustGetInt(path string) int {
+	value, err := r.GetInt(path)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// MustGetBool retrieves a boolean value like GetBool.
+// It panics if the value is missing or cannot be converted.
+func (r *ConfigRegistry) MustGetBool(path string) bool {
+	value, err := r.GetBool(path)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// MustGetFloat retrieves a float64 value like GetFloat.
+// It panics if the value is missing or cannot be converted.
+func (r *ConfigRegistry) MustGetFloat(path string) float64 {
+	value, err := r.GetFloat(path)
+	if err != nil {
+		panic(err)
 	}
+	return value
+}
+
+// MustGetStringArray retrieves a string array like GetStringArray.
+// It panics if the value is missing or cannot be converted.
+func (r *ConfigRegistry) MustGetStringArray(path string) []string {
+	value, err := r.GetStringArray(path)
+	if err != nil {
+		panic(err)
+	}
+	return value
 }
 
 // GetEnvString retrieves a string value from environment variables.
@@ -326,12 +1480,43 @@ func (r *ConfigRegistry) GetEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// GetEnvFloat retrieves a float64 value from environment variables.
+// Returns the default value if the environment variable doesn't exist or cannot be converted.
+func (r *ConfigRegistry) GetEnvFloat(key string, defaultValue float64) float64 {
+	if value, exists := os.LookupEnv(key); exists {
+		if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatVal
+		}
+	}
+	return defaultValue
+}
+
+// GetEnvDuration retrieves a time.Duration value from environment variables.
+// Values use time.ParseDuration syntax, such as "30s" or "1h15m".
+// Returns the default value if the environment variable doesn't exist or cannot be converted.
+func (r *ConfigRegistry) GetEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
+
 // GetEnvBool retrieves a boolean value from environment variables.
-// Returns the default value if the environment variable doesn't exist.
-// The value "true" (case-insensitive) is considered true, all other values are false.
+// Accepts strconv.ParseBool values as well as "yes"/"on" and "no"/"off" (case-insensitive).
+// Returns the default value if the environment variable doesn't exist or is not recognized.
 func (r *ConfigRegistry) GetEnvBool(key string, defaultValue bool) bool {
 	if value, exists := os.LookupEnv(key); exists {
-		return strings.ToLower(value) == "true"
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "yes", "on":
+			return true
+		case "no", "off":
+			return false
+		}
+		if boolVal, err := strconv.ParseBool(strings.TrimSpace(value)); err == nil {
+			return boolVal
+		}
 	}
 	return defaultValue
 }
@@ -354,56 +1539,335 @@ func (r *ConfigRegistry) GetEnvStringArray(key string, defaultValue []string) []
 
 // traverse walks through a nested configuration map using the given path parts.
 // It returns the value at the specified path or an error if the path is invalid.
+// Numeric parts index into slices, so "servers.0.host" reads the first server's host.
+// The parts slice is only read, so cached path parts can be passed directly.
 // Example: traverse(config, []string{"database", "host"})
 func traverse(config map[string]interface{}, parts []string, fullPath string) (interface{}, error) {
-	current := config
-	for i, part := range parts[:len(parts)-1] {
-		next, ok := current[part].(map[string]interface{})
-		if !ok {
-			currentPath := strings.Join(parts[:i+1], ".")
-			if _, exists := current[part]; !exists {
-				return nil, fmt.Errorf("key not found: '%s' in path '%s'", currentPath, fullPath)
+	var current interface{} = config
+	for i, part := range parts {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[part]
+			if !ok {
+				if i == len(parts)-1 {
+					return nil, newPathError(ErrKeyNotFound, fullPath, part, "key not found: '%s' in path '%s'", part, fullPath)
+				}
+				currentPath := strings.Join(parts[:i+1], ".")
+				return nil, newPathError(ErrKeyNotFound, fullPath, currentPath, "key not found: '%s' in path '%s'", currentPath, fullPath)
 			}
-			return nil, fmt.Errorf("value at '%s' in path '%s' is not a map, cannot traverse further", currentPath, fullPath)
+			current = value
+		default:
+			slice := reflect.ValueOf(current)
+			if slice.Kind() != reflect.Slice {
+				currentPath := strings.Join(parts[:i], ".")
+				return nil, newPathError(ErrInvalidPath, fullPath, currentPath, "value at '%s' in path '%s' is not a map or array, cannot traverse further", currentPath, fullPath)
+			}
+			index, err := sliceIndex(slice, part, strings.Join(parts[:i+1], "."), fullPath)
+			if err != nil {
+				return nil, err
+			}
+			current = slice.Index(index).Interface()
 		}
-		current = next
 	}
 
-	lastPart := parts[len(parts)-1]
-	value, ok := current[lastPart]
-	if !ok {
-		return nil, fmt.Errorf("key not found: '%s' in path '%s'", lastPart, fullPath)
-	}
-
-	return value, nil
+	return current, nil
 }
 
 // setValue updates a value in a nested configuration map using the given path parts.
-// It creates intermediate maps if they don't exist.
-// Example: setValue(config, []string{"database", "host"}, "localhost")
-func setValue(config map[string]interface{}, parts []string, value interface{}) error {
-	current := config
-	for _, part := range parts[:len(parts)-1] {
-		next, ok := current[part].(map[string]interface{})
-		if !ok {
+// It creates intermediate maps if they don't exist. Numeric parts index into existing
+// slices, which are updated in place. The parts slice is only read.
+// Example: setValue(config, []string{"database", "host"}, "localhost", "app.database.host")
+func setValue(config map[string]interface{}, parts []string, value interface{}, fullPath string) error {
+	var current interface{} = config
+	for i, part := range parts {
+		last := i == len(parts)-1
+
+		if node, ok := current.(map[string]interface{}); ok {
+			if last {
+				node[part] = value
+				return nil
+			}
+			next := node[part]
+			if !isContainer(next) {
+				next = make(map[string]interface{})
+				node[part] = next
+			}
+			current = next
+			continue
+		}
+
+		slice := reflect.ValueOf(current)
+		currentPath := strings.Join(parts[:i+1], ".")
+		index, err := sliceIndex(slice, part, currentPath, fullPath)
+		if err != nil {
+			return err
+		}
+		elem := slice.Index(index)
+		if last {
+			return assignElem(elem, value, currentPath, fullPath)
+		}
+		next := elem.Interface()
+		if !isContainer(next) {
 			next = make(map[string]interface{})
-			current[part] = next
+			if err := assignElem(elem, next, currentPath, fullPath); err != nil {
+				return err
+			}
+		}
+		current = next
+	}
+
+	return nil
+}
+
+// copyPath returns a shallow copy of config in which every map and slice reached by
+// parts is copied as well. The result can be modified along parts with setValue
+// without affecting readers still holding the original. Traversal stops at the first
+// part that doesn't resolve to a container, leaving the rest for setValue to create.
+func copyPath(config map[string]interface{}, parts []string) map[string]interface{} {
+	root := copyMap(config)
+	var current interface{} = root
+	for _, part := range parts {
+		var next interface{}
+		if node, ok := current.(map[string]interface{}); ok {
+			next = node[part]
+			if !isContainer(next) {
+				break
+			}
+			next = copyContainer(next)
+			node[part] = next
+		} else {
+			slice := reflect.ValueOf(current)
+			index, err := strconv.Atoi(part)
+			if err != nil || index < 0 || index >= slice.Len() {
+				break
+			}
+			next = slice.Index(index).Interface()
+			if !isContainer(next) {
+				break
+			}
+			next = copyContainer(next)
+			slice.Index(index).Set(reflect.ValueOf(next))
 		}
 		current = next
 	}
+	return root
+}
+
+// copyMap returns a shallow copy of a map, or an empty map if it is nil.
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		copied[key] = value
+	}
+	return copied
+}
+
+// deepCopy returns a copy of a value in which all nested maps and slices are copied.
+// Scalars are returned as-is.
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			copied[key] = deepCopy(elem)
+		}
+		return copied
+	case []string:
+		copied := make([]string, len(v))
+		copy(copied, v)
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, elem := range v {
+			copied[i] = deepCopy(elem)
+		}
+		return copied
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Slice:
+		if rv.IsNil() {
+			return value
+		}
+		copied := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			if elem := rv.Index(i).Interface(); elem != nil {
+				copied.Index(i).Set(reflect.ValueOf(deepCopy(elem)))
+			}
+		}
+		return copied.Interface()
+	case reflect.Map:
+		if rv.IsNil() {
+			return value
+		}
+		copied := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			elem := iter.Value()
+			if v := elem.Interface(); v != nil {
+				elem = reflect.ValueOf(deepCopy(v))
+			}
+			copied.SetMapIndex(iter.Key(), elem)
+		}
+		return copied.Interface()
+	default:
+		return value
+	}
+}
+
+// copyContainer returns a shallow copy of a map or slice value.
+func copyContainer(value interface{}) interface{} {
+	if m, ok := value.(map[string]interface{}); ok {
+		return copyMap(m)
+	}
+	slice := reflect.ValueOf(value)
+	copied := reflect.MakeSlice(slice.Type(), slice.Len(), slice.Len())
+	reflect.Copy(copied, slice)
+	return copied.Interface()
+}
+
+// isContainer reports whether a value can be traversed by a path part.
+func isContainer(value interface{}) bool {
+	if _, ok := value.(map[string]interface{}); ok {
+		return true
+	}
+	return value != nil && reflect.TypeOf(value).Kind() == reflect.Slice
+}
+
+// sliceIndex parses a path part as an index into the given slice.
+// Returns an error if the part is not numeric or the index is out of range.
+func sliceIndex(slice reflect.Value, part, currentPath, fullPath string) (int, error) {
+	index, err := strconv.Atoi(part)
+	if err != nil {
+		return 0, newPathError(ErrInvalidPath, fullPath, currentPath, "invalid array index: '%s' in path '%s'", currentPath, fullPath)
+	}
+	if index < 0 || index >= slice.Len() {
+		return 0, newPathError(ErrKeyNotFound, fullPath, currentPath, "array index out of range: '%s' in path '%s' (length %d)", currentPath, fullPath, slice.Len())
+	}
+	return index, nil
+}
 
-	current[parts[len(parts)-1]] = value
+// assignElem stores a value into a slice element, checking type compatibility.
+func assignElem(elem reflect.Value, value interface{}, currentPath, fullPath string) error {
+	if value == nil {
+		elem.Set(reflect.Zero(elem.Type()))
+		return nil
+	}
+	v := reflect.ValueOf(value)
+	if !v.Type().AssignableTo(elem.Type()) {
+		err := newTypeError(fullPath, elem.Type().String(), value, "cannot set value of type %T at '%s' in path '%s': array element type is %v", value, currentPath, fullPath, elem.Type())
+		err.Segment = currentPath
+		return err
+	}
+	elem.Set(v)
 	return nil
 }
 
-// Unmarshal deserializes a configuration section into a struct
+// mergeMaps recursively merges src into dst.
+// Nested maps present in both are merged into a copy, so only dst itself is modified,
+// and any other value in src overwrites dst with a deep copy, so later changes to the
+// caller's slices don't leak into dst.
+func mergeMaps(dst, src map[string]interface{}) {
+	for key, srcValue := range src {
+		srcMap, srcIsMap := srcValue.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			merged := copyMap(dstMap)
+			mergeMaps(merged, srcMap)
+			dst[key] = merged
+			continue
+		}
+		if srcIsMap {
+			copied := make(map[string]interface{}, len(srcMap))
+			mergeMaps(copied, srcMap)
+			dst[key] = copied
+			continue
+		}
+		dst[key] = deepCopy(srcValue)
+	}
+}
+
+// Unmarshal deserializes a configuration section into a struct.
+// Unexported fields are left alone, and a field that fails to decode is reported
+// with its config key, its struct field name and its full path.
 func (r *ConfigRegistry) Unmarshal(section string, v interface{}) error {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	return r.unmarshal(section, v)
+}
+
+// SetWeaklyTypedInput controls how leniently Unmarshal, UnmarshalKey and Bind convert
+// values to the types of struct fields. It is enabled by default, so strings parse into
+// numbers and bools, numbers format into strings and fractions are truncated. When
+// disabled, values must already have a compatible kind: strings only fill strings, bools
+// only fill bools, and numbers fill numeric fields only if no fraction is lost, so whole
+// JSON numbers still fill int fields.
+func (r *ConfigRegistry) SetWeaklyTypedInput(weak bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.checkSealed("*") != nil {
+		return
+	}
+	r.strictTypes = !weak
+}
+
+// RegisterDecodeHook adds a hook that Unmarshal, UnmarshalKey and Bind pass every
+// field value through before the built-in conversions. A hook receives the type of the
+// value, the type of the field and the value, and returns the value to convert instead;
+// hooks that don't handle a pair of types should return the value unchanged.
+// Hooks run in registration order, and an error from a hook fails the field.
+// Example: RegisterDecodeHook(func(from, to reflect.Type, data interface{}) (interface{}, error) { ... })
+func (r *ConfigRegistry) RegisterDecodeHook(hook configContracts.DecodeHook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.checkSealed("*") != nil {
+		return
+	}
+	r.hooks = append(r.hooks, hook)
+}
+
+// Bind unmarshals a configuration section into a struct and keeps it in sync,
+// re-populating it whenever the section is reloaded by Refresh or RefreshSection.
+// The registry holds on to the pointer, so the caller must retain the struct for
+// as long as it should be updated. Reads of the struct are not synchronized with
+// refreshes; guard it yourself if it is read concurrently with a reload.
+// Example: Bind("database", &dbConfig)
+func (r *ConfigRegistry) Bind(section string, v interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.unmarshal(section, v); err != nil {
+		return err
+	}
+	r.bound[section] = append(r.bound[section], v)
+	return nil
+}
+
+// rebind re-populates the structs bound to a section with its current values.
+// Each struct is replaced only if unmarshaling succeeds, so stale keys are cleared.
+// The caller must hold the write lock.
+func (r *ConfigRegistry) rebind(section string) error {
+	for _, v := range r.bound[section] {
+		target := reflect.ValueOf(v).Elem()
+		fresh := reflect.New(target.Type())
+		if err := r.unmarshal(section, fresh.Interface()); err != nil {
+			return err
+		}
+		target.Set(fresh.Elem())
+	}
+	return nil
+}
+
+// unmarshal performs the actual section deserialization.
+// The caller must hold the read or write lock.
+func (r *ConfigRegistry) unmarshal(section string, v interface{}) error {
 	config, ok := r.configs[section]
 	if !ok {
-		return fmt.Errorf("config section not found: '%s'", section)
+		return newPathError(ErrSectionNotFound, section, section, "config section not found: '%s'", section)
 	}
 
 	// Use reflection to map config values to struct fields
@@ -412,7 +1876,15 @@ func (r *ConfigRegistry) Unmarshal(section string, v interface{}) error {
 		return fmt.Errorf("unmarshal target must be a non-nil pointer")
 	}
 
-	return unmarshalInto(config, val.Elem())
+	opts := r.decodeOptions()
+	opts.path = section
+	return unmarshalInto(config, val.Elem(), opts)
+}
+
+// decodeOptions returns the options Unmarshal converts values with.
+// The caller must hold the read or write lock.
+func (r *ConfigRegistry) decodeOptions() decodeOptions {
+	return decodeOptions{hooks: r.hooks, strict: r.strictTypes}
 }
 
 // UnmarshalKey deserializes a specific configuration key into a struct
@@ -422,9 +1894,14 @@ func (r *ConfigRegistry) UnmarshalKey(path string, v interface{}) error {
 		return err
 	}
 
+	r.mu.RLock()
+	opts := r.decodeOptions()
+	r.mu.RUnlock()
+	opts.path = path
+
 	configMap, ok := value.(map[string]interface{})
 	if !ok {
-		return fmt.Errorf("value at '%s' is not a map", path)
+		return newTypeError(path, "map[string]interface {}", value, "value at '%s' is not a map", path)
 	}
 
 	val := reflect.ValueOf(v)
@@ -432,17 +1909,43 @@ func (r *ConfigRegistry) UnmarshalKey(path string, v interface{}) error {
 		return fmt.Errorf("unmarshal target must be a non-nil pointer")
 	}
 
-	return unmarshalInto(configMap, val.Elem())
+	return unmarshalInto(configMap, val.Elem(), opts)
+}
+
+// decodeOptions controls how unmarshalInto converts values to the types of their fields.
+// The zero value converts leniently without hooks.
+type decodeOptions struct {
+	hooks  []configContracts.DecodeHook
+	strict bool
+
+	// Path of the map being decoded, such as the section name, used in errors
+	path string
 }
 
 // Helper function to unmarshal config into a struct
-func unmarshalInto(config map[string]interface{}, val reflect.Value) error {
+func unmarshalInto(config map[string]interface{}, val reflect.Value, opts decodeOptions) error {
 	typ := val.Type()
 
 	for i := 0; i < typ.NumField(); i++ {
 		field := typ.Field(i)
 		fieldVal := val.Field(i)
 
+		// Embedded structs without a tag read their fields from the same level,
+		// so promoted fields bind like the struct's own fields
+		if field.Anonymous && field.Tag.Get("config") == "" {
+			if embedded, ok := embeddedStruct(fieldVal); ok {
+				if err := unmarshalInto(config, embedded, opts); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		// Unexported fields can't be set, so they are left alone
+		if !field.IsExported() {
+			continue
+		}
+
 		// Get the config key from struct tag or field name
 		key := field.Tag.Get("config")
 		if key == "" {
@@ -452,29 +1955,139 @@ func unmarshalInto(config map[string]interface{}, val reflect.Value) error {
 			continue // Skip this field
 		}
 
-		value, ok := config[key]
+		value, ok, err := fieldValue(config, key)
+		if err != nil {
+			return err
+		}
 		if !ok {
 			// Check if field is required
 			if field.Tag.Get("required") == "true" {
-				return fmt.Errorf("required field '%s' not found in configuration", key)
+				return newPathError(ErrKeyNotFound, key, key, "required field '%s' not found in configuration", key)
 			}
 			continue
 		}
 
-		if err := setField(fieldVal, value); err != nil {
-			return fmt.Errorf("error setting field '%s': %w", key, err)
+		fieldOpts := opts
+		fieldOpts.path = joinKey(opts.path, key)
+		if err := setField(fieldVal, value, field.Tag, fieldOpts); err != nil {
+			pathErr := newTypeError(fieldOpts.path, fieldVal.Type().String(), value, "error setting field '%s' (%s) at '%s': %w", key, field.Name, fieldOpts.path, err)
+			pathErr.Segment = key
+			return pathErr
 		}
 	}
 
 	return nil
 }
 
-// setField sets a value to a struct field using reflection
-func setField(field reflect.Value, value interface{}) error {
+// embeddedStruct returns the struct value of an embedded field, allocating nil
+// struct pointers. It reports false for fields that aren't structs or struct pointers,
+// and for nil pointers to unexported types, which can't be allocated.
+func embeddedStruct(field reflect.Value) (reflect.Value, bool) {
+	if field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct {
+		if field.IsNil() {
+			if !field.CanSet() {
+				return reflect.Value{}, false
+			}
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		field = field.Elem()
+	}
+	return field, field.Kind() == reflect.Struct
+}
+
+// fieldValue looks up the value for a struct field's config key.
+// Keys that aren't present as-is are treated as paths, so a tag like
+// `config:"options.pool.max_connections"` reaches into nested maps.
+func fieldValue(config map[string]interface{}, key string) (interface{}, bool, error) {
+	if value, ok := config[key]; ok {
+		return value, true, nil
+	}
+	if !strings.ContainsAny(key, ".[") {
+		return nil, false, nil
+	}
+
+	value, err := traverse(config, splitPath(key), key)
+	if errors.Is(err, ErrKeyNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// setField sets a value to a struct field using reflection.
+// The struct tag of the field controls type-specific parsing, such as `timeformat`,
+// and any `validate` rules are checked once the value has been assigned.
+func setField(field reflect.Value, value interface{}, tag reflect.StructTag, opts decodeOptions) error {
+	if err := assignField(field, value, tag, opts); err != nil {
+		return err
+	}
+	if rules, ok := tag.Lookup("validate"); ok {
+		return validateTag(field, rules)
+	}
+	return nil
+}
+
+// assignField converts a value to the type of a struct field and assigns it.
+// Decode hooks run first, in registration order, each receiving the previous result,
+// and a result that hooks converted to the field's type is assigned directly.
+// In strict mode values must already have a kind compatible with the field.
+func assignField(field reflect.Value, value interface{}, tag reflect.StructTag, opts decodeOptions) error {
 	if !field.CanSet() {
 		return fmt.Errorf("field cannot be set")
 	}
 
+	if len(opts.hooks) > 0 {
+		from := reflect.TypeOf(value)
+		for _, hook := range opts.hooks {
+			converted, err := hook(reflect.TypeOf(value), field.Type(), value)
+			if err != nil {
+				return err
+			}
+			value = converted
+		}
+		// Values a hook converted to the field's type are assigned as they are
+		if to := reflect.TypeOf(value); to != nil && to != from && to.AssignableTo(field.Type()) {
+			field.Set(reflect.ValueOf(value))
+			return nil
+		}
+	}
+
+	// Types that decode themselves take precedence over the built-in conversions
+	if decoder, ok := configDecoder(field); ok {
+		return decoder.FromConfig(deepCopy(value))
+	}
+
+	// Concrete types with their own parsing must be handled before their kinds
+	switch field.Type() {
+	case durationType:
+		d, err := toDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+
+	case timeType:
+		layout := tag.Get("timeformat")
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		t, err := toTime(value, layout)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	if opts.strict {
+		if err := checkStrictKind(field.Type(), value); err != nil {
+			return err
+		}
+	}
+
 	switch field.Kind() {
 	case reflect.String:
 		str, err := toString(value)
@@ -524,10 +2137,16 @@ func setField(field reflect.Value, value interface{}) error {
 
 	case reflect.Struct:
 		if m, ok := value.(map[string]interface{}); ok {
-			return unmarshalInto(m, field)
+			return unmarshalInto(m, field, opts)
 		}
 		return fmt.Errorf("cannot set struct field with value of type %T", value)
 
+	case reflect.Ptr:
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		return assignField(field.Elem(), value, tag, opts)
+
 	default:
 		return fmt.Errorf("unsupported field type: %v", field.Type())
 	}
@@ -535,6 +2154,65 @@ func setField(field reflect.Value, value interface{}) error {
 	return nil
 }
 
+// checkStrictKind reports an error unless value converts to typ without reinterpretation:
+// strings to strings, bools to bools, and numbers to numbers as long as no fraction is
+// dropped. String slices must be slices of strings.
+func checkStrictKind(typ reflect.Type, value interface{}) error {
+	rv := reflect.ValueOf(value)
+	ok := true
+	switch typ.Kind() {
+	case reflect.String, reflect.Bool:
+		ok = rv.Kind() == typ.Kind()
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		case reflect.Float32, reflect.Float64:
+			ok = rv.Float() == math.Trunc(rv.Float())
+		default:
+			ok = false
+		}
+
+	case reflect.Float32, reflect.Float64:
+		_, ok = numericValue(value)
+
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.String {
+			ok = rv.Kind() == reflect.Slice
+			for i := 0; ok && i < rv.Len(); i++ {
+				_, ok = rv.Index(i).Interface().(string)
+			}
+		}
+	}
+	if !ok {
+		return fmt.Errorf("cannot convert %T to %v with weakly typed input disabled", value, typ)
+	}
+	return nil
+}
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+	decoderType  = reflect.TypeOf((*configContracts.ConfigDecoder)(nil)).Elem()
+)
+
+// configDecoder returns the ConfigDecoder implemented by a field, if any.
+// Nil pointer fields are allocated so their decoder can be called.
+func configDecoder(field reflect.Value) (configContracts.ConfigDecoder, bool) {
+	if field.Kind() == reflect.Ptr && field.Type().Implements(decoderType) {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		return field.Interface().(configContracts.ConfigDecoder), true
+	}
+	if field.CanAddr() && field.Addr().Type().Implements(decoderType) {
+		return field.Addr().Interface().(configContracts.ConfigDecoder), true
+	}
+	return nil, false
+}
+
 // Helper functions for type conversion
 func toString(value interface{}) (string, error) {
 	switch v := value.(type) {
@@ -564,6 +2242,35 @@ func toInt64(value interface{}) (int64, error) {
 	}
 }
 
+// toInteger converts a whole number of any numeric kind, or a string holding one, to an
+// int64. Fractions and values outside the int64 range are rejected rather than truncated.
+func toInteger(value interface{}) (int64, error) {
+	if s, ok := value.(string); ok {
+		return strconv.ParseInt(s, 10, 64)
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("value %v overflows int64", value)
+		}
+		return int64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if f != math.Trunc(f) {
+			return 0, fmt.Errorf("value %v is not an integer", value)
+		}
+		// -MinInt64 is a power of two, so unlike MaxInt64 it is exact as a float64
+		if f < math.MinInt64 || f >= -math.MinInt64 {
+			return 0, fmt.Errorf("value %v overflows int64", value)
+		}
+		return int64(f), nil
+	}
+	return 0, fmt.Errorf("cannot convert %T to an integer", value)
+}
+
 func toUint64(value interface{}) (uint64, error) {
 	switch v := value.(type) {
 	case uint:
@@ -617,7 +2324,9 @@ func toBool(value interface{}) (bool, error) {
 func toStringSlice(value interface{}) ([]string, error) {
 	switch v := value.(type) {
 	case []string:
-		return v, nil
+		copied := make([]string, len(v))
+		copy(copied, v)
+		return copied, nil
 	case string:
 		if v == "" {
 			return []string{}, nil
@@ -637,3 +2346,82 @@ func toStringSlice(value interface{}) ([]string, error) {
 		return nil, fmt.Errorf("cannot convert %T to []string", value)
 	}
 }
+
+func toDuration(value interface{}) (time.Duration, error) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case int:
+		return time.Duration(v), nil
+	case int64:
+		return time.Duration(v), nil
+	case float64:
+		return time.Duration(v), nil
+	case string:
+		return time.ParseDuration(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to time.Duration", value)
+	}
+}
+
+func toTime(value interface{}, layout string) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		return time.Parse(layout, v)
+	default:
+		return time.Time{}, fmt.Errorf("cannot convert %T to time.Time", value)
+	}
+}
+
+// byteUnits maps size suffixes to their multipliers, matched case-insensitively.
+var byteUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+}
+
+func toBytes(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case string:
+		s := strings.TrimSpace(v)
+		split := strings.IndexFunc(s, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.'
+		})
+		if split == -1 {
+			split = len(s)
+		}
+
+		number, err := strconv.ParseFloat(s[:split], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid size '%s'", v)
+		}
+		multiplier, ok := byteUnits[strings.ToLower(strings.TrimSpace(s[split:]))]
+		if !ok {
+			return 0, fmt.Errorf("unknown size unit in '%s'", v)
+		}
+
+		size := number * multiplier
+		if size >= math.MaxInt64 {
+			return 0, fmt.Errorf("size '%s' overflows int64", v)
+		}
+		return int64(size), nil
+	default:
+		n, err := toInteger(value)
+		if err != nil {
+			return 0, err
+		}
+		if n < 0 {
+			return 0, fmt.Errorf("size %v is negative", value)
+		}
+		return n, nil
+	}
+}
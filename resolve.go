@@ -0,0 +1,70 @@
+package gonfig
+
+// Resolve returns the value at path along with its origin, applying the full lookup
+// precedence in one place:
+//
+//  1. flags bound with BindFlag that were set ("flag")
+//  2. values written with Set or its variants ("set")
+//  3. environment overrides enabled with EnableEnvOverride ("env")
+//  4. values from loaders and other writes, with the origins reported by Origin
+//  5. the value of the fallback set with SetFallback, with the fallback's origin
+//  6. the Default of the schema field for the path ("default")
+//
+// Get and the typed accessors apply the same precedence except for schema defaults,
+// which they never fall back to. Section schemas registered with RegisterSchema are
+// checked for a default before the schema attached with AttachSchema.
+// Example: value, origin, err := Resolve("database.host")
+func (r *ConfigRegistry) Resolve(path string) (interface{}, string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	value, origin, err := r.resolve(path, true)
+	if err != nil {
+		return nil, "", err
+	}
+	return deepCopy(value), origin, nil
+}
+
+// resolve looks up path and reports its origin, falling back to the schema default
+// for a missing path if defaults is set.
+// The caller must hold the read or write lock.
+func (r *ConfigRegistry) resolve(path string, defaults bool) (interface{}, string, error) {
+	value, source, err := r.lookupSource(path)
+	if err != nil {
+		if defaults && isMissing(err) {
+			if def, ok := r.schemaDefault(path); ok {
+				return def, originDefault, nil
+			}
+		}
+		return nil, "", err
+	}
+
+	switch source {
+	case sourceFlag:
+		return value, originFlag, nil
+	case sourceEnv:
+		return value, originEnv, nil
+	case sourceFallback:
+		return r.resolve(r.fallbacks[joinParts(r.pathCache.shared(path))], defaults)
+	}
+	return value, r.storedOrigin(path, r.pathCache.shared(path)), nil
+}
+
+// schemaDefault returns the default of the schema field for path, from the schema
+// registered for its section or the attached schema.
+// The caller must hold the read or write lock.
+func (r *ConfigRegistry) schemaDefault(path string) (interface{}, bool) {
+	parts := r.pathCache.shared(path)
+	for _, schema := range []interface{}{r.schemas[parts[0]], r.schema} {
+		s, ok := schema.(*ConfigSchema)
+		if !ok || s == nil {
+			continue
+		}
+		for _, key := range []string{path, joinParts(parts)} {
+			if field, ok := s.Fields[key]; ok && field.Default != nil {
+				return field.Default, true
+			}
+		}
+	}
+	return nil, false
+}
@@ -1,24 +1,32 @@
 package gonfig
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	configContracts "github.com/centraunit/gonfig/contracts"
 )
 
-// ConfigSchemaField represents a field in the configuration schema
-type ConfigSchemaField struct {
-	Type      reflect.Kind
-	Required  bool
-	Default   interface{}
-	Validator func(interface{}) error
-}
-
 // Schema defines the structure and validation rules for configuration
 type ConfigSchema struct {
-	Fields map[string]configContracts.ConfigSchemaField
+	Fields   map[string]configContracts.ConfigSchemaField
+	Rules    []func(config map[string]interface{}) error
+	failFast bool
+
+	// Compiled field patterns, keyed by pattern
+	patterns map[string]compiledPattern
+}
+
+// compiledPattern is the result of compiling a field pattern.
+type compiledPattern struct {
+	re  *regexp.Regexp
+	err error
 }
 
 // NewConfigSchema creates a new schema instance
@@ -28,38 +36,151 @@ func NewConfigSchema() configContracts.ConfigSchema {
 	}
 }
 
-// AddField adds a field to the schema
+// AddField adds a field to the schema.
+// A Pattern is compiled once here; an invalid pattern fails every validation of the field.
 func (s *ConfigSchema) AddField(path string, field configContracts.ConfigSchemaField) {
 	s.Fields[path] = field
 
+	if field.Pattern != "" {
+		if _, ok := s.patterns[field.Pattern]; !ok {
+			if s.patterns == nil {
+				s.patterns = make(map[string]compiledPattern)
+			}
+			re, err := regexp.Compile(field.Pattern)
+			s.patterns[field.Pattern] = compiledPattern{re: re, err: err}
+		}
+	}
+}
+
+// AddRule adds a cross-field validation rule to the schema.
+// Rules receive the whole configuration and run after per-field validation,
+// so they can express conditions spanning several fields.
+// Example: AddRule(func(c map[string]interface{}) error { ... })
+func (s *ConfigSchema) AddRule(rule func(config map[string]interface{}) error) {
+	s.Rules = append(s.Rules, rule)
 }
 
-// Validate checks if a configuration matches the schema
+// SetFailFast controls whether validation stops at the first failing field.
+// By default all failures are collected and returned as a single joined error.
+func (s *ConfigSchema) SetFailFast(failFast bool) {
+	s.failFast = failFast
+}
+
+// Validate checks if a configuration matches the schema.
+// Missing optional fields with a default are populated in the given map.
 func (s *ConfigSchema) Validate(config map[string]interface{}) error {
-	for path, field := range s.Fields {
-		parts := strings.Split(path, ".")
-		value, err := traverse(config, parts, path)
-		if err != nil {
-			if field.Required {
-				return fmt.Errorf("required field missing: %s", path)
+	return s.validate(
+		func(path string) (interface{}, error) {
+			return traverse(config, splitPath(path), path)
+		},
+		func(path string, value interface{}) error {
+			return setValue(config, splitPath(path), value, path)
+		},
+		func() map[string]interface{} {
+			return config
+		},
+	)
+}
+
+// ValidateRegistry checks the live contents of a registry against the schema.
+// Each field path is resolved with Get, and missing optional fields with a default
+// are populated with Set. Rules receive the sections referenced by the schema fields.
+func (s *ConfigSchema) ValidateRegistry(registry configContracts.ConfigRegistry) error {
+	return s.validate(registry.Get, registry.Set, func() map[string]interface{} {
+		config := make(map[string]interface{})
+		for _, path := range s.paths() {
+			section := splitPath(path)[0]
+			if _, ok := config[section]; ok {
+				continue
 			}
-			if field.Default != nil {
-				if err := setValue(config, parts, field.Default); err != nil {
-					return fmt.Errorf("failed to set default value for %s: %w", path, err)
+			if value, err := registry.Get(section); err == nil {
+				config[section] = value
+			}
+		}
+		return config
+	})
+}
+
+// validate runs every schema field against values resolved by get, populating
+// defaults with set, then runs the rules against the configuration returned by
+// config. Failures are joined unless fail-fast mode is enabled.
+func (s *ConfigSchema) validate(get func(path string) (interface{}, error), set func(path string, value interface{}) error, config func() map[string]interface{}) error {
+	var errs []error
+	for _, path := range s.paths() {
+		if err := s.validatePath(path, get, set); err != nil {
+			if s.failFast {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+
+	if len(s.Rules) > 0 {
+		values := config()
+		for _, rule := range s.Rules {
+			if err := rule(values); err != nil {
+				if s.failFast {
+					return err
 				}
+				errs = append(errs, err)
 			}
-			continue
 		}
+	}
+	return errors.Join(errs...)
+}
 
-		if err := validateValue(value, field); err != nil {
-			return fmt.Errorf("validation failed for %s: %w", path, err)
+// validatePath validates a single schema field.
+func (s *ConfigSchema) validatePath(path string, get func(path string) (interface{}, error), set func(path string, value interface{}) error) error {
+	field := s.Fields[path]
+	value, err := get(path)
+	if err != nil {
+		if field.Required {
+			return fmt.Errorf("required field missing: %s", path)
+		}
+		if field.Default != nil {
+			if _, err := parseFormat(field.Default, field.ParseAs); err != nil {
+				return fmt.Errorf("invalid default for %s: %w", path, err)
+			}
+			if err := set(path, field.Default); err != nil {
+				return fmt.Errorf("failed to set default value for %s: %w", path, err)
+			}
 		}
+		return nil
+	}
+
+	if err := s.validateValue(value, field); err != nil {
+		return fmt.Errorf("validation failed for %s: %w", path, err)
 	}
 	return nil
 }
 
-// validateValue checks if a value matches the schema field requirements
-func validateValue(value interface{}, field configContracts.ConfigSchemaField) error {
+// paths returns the schema field paths in sorted order so errors are reported deterministically.
+func (s *ConfigSchema) paths() []string {
+	paths := make([]string, 0, len(s.Fields))
+	for path := range s.Fields {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// ValidateField checks a single value against the schema field registered for path.
+// Returns nil if the schema has no field for the path.
+func (s *ConfigSchema) ValidateField(path string, value interface{}) error {
+	field, ok := s.Fields[path]
+	if !ok {
+		return nil
+	}
+
+	if err := s.validateValue(value, field); err != nil {
+		return fmt.Errorf("validation failed for %s: %w", path, err)
+	}
+	return nil
+}
+
+// validateValue checks if a value matches the schema field requirements.
+// Fields with a ParseAs format check the parsed value, and may leave Type unset.
+func (s *ConfigSchema) validateValue(value interface{}, field configContracts.ConfigSchemaField) error {
 	if value == nil {
 		if field.Required {
 			return fmt.Errorf("required field is nil")
@@ -67,11 +188,47 @@ func validateValue(value interface{}, field configContracts.ConfigSchemaField) e
 		return nil
 	}
 
+	value, err := parseFormat(value, field.ParseAs)
+	if err != nil {
+		return err
+	}
+
 	valueType := reflect.TypeOf(value).Kind()
-	if valueType != field.Type {
+	if field.Type != reflect.Invalid && !hasKind(reflect.ValueOf(value), field.Type) {
 		return fmt.Errorf("expected type %v, got %v", field.Type, valueType)
 	}
 
+	if field.ElemType != reflect.Invalid && (valueType == reflect.Slice || valueType == reflect.Map) {
+		if err := validateElements(value, field.ElemType); err != nil {
+			return err
+		}
+	}
+
+	if field.Pattern != "" && valueType == reflect.String {
+		re, err := s.pattern(field.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", field.Pattern, err)
+		}
+		if !re.MatchString(reflect.ValueOf(value).String()) {
+			return fmt.Errorf("value does not match pattern %q", field.Pattern)
+		}
+	}
+
+	if field.Min != nil || field.Max != nil {
+		if n, ok := numericValue(value); ok {
+			if field.Min != nil && n < *field.Min {
+				return fmt.Errorf("value %v is below minimum %v", value, *field.Min)
+			}
+			if field.Max != nil && n > *field.Max {
+				return fmt.Errorf("value %v is above maximum %v", value, *field.Max)
+			}
+		}
+	}
+
+	if len(field.Enum) > 0 && !containsValue(field.Enum, value) {
+		return fmt.Errorf("value %v is not one of %v", value, field.Enum)
+	}
+
 	if field.Validator != nil {
 		if err := field.Validator(value); err != nil {
 			return err
@@ -80,3 +237,253 @@ func validateValue(value interface{}, field configContracts.ConfigSchemaField) e
 
 	return nil
 }
+
+// pattern returns the compiled form of a field pattern. Patterns of fields added to
+// Fields directly, rather than with AddField, are compiled on every call.
+func (s *ConfigSchema) pattern(pattern string) (*regexp.Regexp, error) {
+	if compiled, ok := s.patterns[pattern]; ok {
+		return compiled.re, compiled.err
+	}
+	return regexp.Compile(pattern)
+}
+
+// parseFormat parses value in the format of a schema field.
+func parseFormat(value interface{}, format configContracts.ValueFormat) (interface{}, error) {
+	switch format {
+	case configContracts.FormatDuration:
+		d, err := toDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration: %w", err)
+		}
+		return d, nil
+	case configContracts.FormatByteSize:
+		n, err := toBytes(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid byte size: %w", err)
+		}
+		return n, nil
+	case configContracts.FormatInteger:
+		n, err := toInteger(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer: %w", err)
+		}
+		return n, nil
+	case configContracts.FormatNumber:
+		if str, ok := value.(string); ok {
+			f, err := strconv.ParseFloat(str, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number: %w", err)
+			}
+			return f, nil
+		}
+		f, ok := numericValue(value)
+		if !ok {
+			return nil, fmt.Errorf("invalid number: found type %T", value)
+		}
+		return f, nil
+	}
+	return value, nil
+}
+
+// numericValue returns value as a float64 if it has an integer or floating-point kind.
+func numericValue(value interface{}) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// containsValue reports whether value is deeply equal to one of values.
+func containsValue(values []interface{}, value interface{}) bool {
+	n, numeric := numericValue(value)
+	for _, allowed := range values {
+		if reflect.DeepEqual(allowed, value) {
+			return true
+		}
+		// Numbers match across kinds, so 8080 allows a port loaded from JSON as 8080.0
+		if m, ok := numericValue(allowed); ok && numeric && m == n {
+			return true
+		}
+	}
+	return false
+}
+
+// validateElements checks that every element of a slice, or every value of a map,
+// has the expected kind. Elements held in interfaces are checked by their dynamic kind.
+func validateElements(value interface{}, elemType reflect.Kind) error {
+	rv := reflect.ValueOf(value)
+
+	elem := func(v reflect.Value) reflect.Value {
+		if v.Kind() == reflect.Interface {
+			return v.Elem()
+		}
+		return v
+	}
+
+	if rv.Kind() == reflect.Map {
+		iter := rv.MapRange()
+		for iter.Next() {
+			if v := elem(iter.Value()); !hasKind(v, elemType) {
+				return fmt.Errorf("expected element type %v for key '%v', got %v", elemType, iter.Key().Interface(), v.Kind())
+			}
+		}
+		return nil
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		if v := elem(rv.Index(i)); !hasKind(v, elemType) {
+			return fmt.Errorf("expected element type %v at index %d, got %v", elemType, i, v.Kind())
+		}
+	}
+	return nil
+}
+
+// hasKind reports whether value has kind. Numbers of another numeric kind also match
+// when they convert without loss, since loaders differ in how they store numbers: JSON
+// stores every number as float64, so any whole number matches an integer kind, and
+// any number matches a floating-point kind.
+func hasKind(value reflect.Value, kind reflect.Kind) bool {
+	if value.Kind() == kind {
+		return true
+	}
+	if _, ok := numericValue(valueInterface(value)); !ok {
+		return false
+	}
+	switch {
+	case integerKind(kind):
+		n, err := toInteger(value.Interface())
+		return err == nil && (n >= 0 || kind < reflect.Uint)
+	case kind == reflect.Float32 || kind == reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// integerKind reports whether kind is a signed or unsigned integer kind.
+func integerKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Uint64
+}
+
+// valueInterface returns the value held by v, or nil if v is the zero Value.
+func valueInterface(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// SchemaFromStruct builds a schema by reflecting over a struct, describing the keys
+// Unmarshal reads. Field paths come from the `config` tag (or the lowercased field
+// name), nested structs and struct pointers produce dotted paths, and embedded structs
+// without a tag add their fields at the same level. `required:"true"` marks a field as
+// required and `default:"..."` provides a default converted to the field's type.
+// Numeric fields accept the values Unmarshal converts, such as whole numbers loaded
+// from JSON as float64 or numeric strings read from the environment, and duration
+// fields parse their values, so strings like "30s" are accepted, time.Time
+// fields accept times and strings in their `timeformat` layout, and fields whose type
+// implements ConfigDecoder accept values of any kind.
+// The struct mirrors the whole configuration, so top-level fields name sections.
+func SchemaFromStruct(v interface{}) (configContracts.ConfigSchema, error) {
+	typ := reflect.TypeOf(v)
+	if typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("schema source must be a struct or pointer to struct, got %T", v)
+	}
+
+	schema := &ConfigSchema{
+		Fields: make(map[string]configContracts.ConfigSchemaField),
+	}
+	if err := addStructFields(schema, typ, ""); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// addStructFields adds a schema field for every field of typ that Unmarshal sets, under prefix.
+func addStructFields(schema *ConfigSchema, typ reflect.Type, prefix string) error {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		// Embedded structs without a tag read their fields from the same level
+		if field.Anonymous && field.Tag.Get("config") == "" {
+			if embedded := derefType(field.Type); embedded.Kind() == reflect.Struct {
+				if err := addStructFields(schema, embedded, prefix); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		key := field.Tag.Get("config")
+		if key == "" {
+			key = strings.ToLower(field.Name)
+		}
+		if key == "-" {
+			continue
+		}
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		// Pointers are allocated by Unmarshal, so they describe the values they point to
+		fieldType := derefType(field.Type)
+		if fieldType.Kind() == reflect.Struct && !decodesItself(fieldType) {
+			if err := addStructFields(schema, fieldType, path); err != nil {
+				return err
+			}
+			continue
+		}
+
+		schemaField := configContracts.ConfigSchemaField{
+			Type:     fieldType.Kind(),
+			Required: field.Tag.Get("required") == "true",
+		}
+		switch {
+		case reflect.PointerTo(fieldType).Implements(decoderType):
+			// Decoders accept whatever their FromConfig does
+			schemaField.Type = reflect.Invalid
+		case fieldType == timeType:
+			layout := field.Tag.Get("timeformat")
+			if layout == "" {
+				layout = time.RFC3339
+			}
+			schemaField.Type = reflect.Invalid
+			schemaField.Validator = func(value interface{}) error {
+				_, err := toTime(value, layout)
+				return err
+			}
+		case fieldType == durationType:
+			schemaField.ParseAs = configContracts.FormatDuration
+		case integerKind(fieldType.Kind()):
+			// Unmarshal converts numeric strings, such as those read from the environment
+			schemaField.ParseAs = configContracts.FormatInteger
+		case fieldType.Kind() == reflect.Float32 || fieldType.Kind() == reflect.Float64:
+			schemaField.ParseAs = configContracts.FormatNumber
+		case fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Map:
+			schemaField.ElemType = fieldType.Elem().Kind()
+		}
+
+		if tag, ok := field.Tag.Lookup("default"); ok {
+			def := reflect.New(fieldType).Elem()
+			if err := setField(def, tag, field.Tag, decodeOptions{}); err != nil {
+				return fmt.Errorf("invalid default for '%s': %w", path, err)
+			}
+			schemaField.Default = def.Interface()
+		}
+
+		schema.AddField(path, schemaField)
+	}
+	return nil
+}
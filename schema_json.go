@@ -0,0 +1,158 @@
+package gonfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+
+	configContracts "github.com/centraunit/gonfig/contracts"
+)
+
+// jsonSchema is the subset of a JSON Schema document that LoadJSONSchema understands.
+type jsonSchema struct {
+	Type                 interface{}            `json:"type"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	Required             []string               `json:"required"`
+	Default              interface{}            `json:"default"`
+	Pattern              string                 `json:"pattern"`
+	Enum                 []interface{}          `json:"enum"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+	Items                *jsonSchema            `json:"items"`
+	AdditionalProperties interface{}            `json:"additionalProperties"`
+}
+
+// jsonSchemaKinds maps JSON Schema types to the kinds gonfig validates against.
+// Integers map to int, the type loaders written in Go produce for whole numbers; whole
+// numbers of other kinds, such as the float64 values JSON loaders produce, match it too.
+var jsonSchemaKinds = map[string]reflect.Kind{
+	"string":  reflect.String,
+	"integer": reflect.Int,
+	"number":  reflect.Float64,
+	"boolean": reflect.Bool,
+	"array":   reflect.Slice,
+	"object":  reflect.Map,
+}
+
+// LoadJSONSchema builds a schema from a JSON Schema document describing the whole
+// configuration, so the top-level properties name sections. Nested objects with
+// properties produce dotted field paths, and every other property becomes a field with
+// its type, its required flag from the parent's "required" list, its default, its enum,
+// its minimum and maximum and, for strings, its pattern.
+// The element type of arrays comes from "items" and of other objects from a schema in
+// "additionalProperties". A type list such as ["string", "null"] uses its first non-null
+// type, and properties without a type are skipped. Other keywords, such as $ref, allOf
+// or patternProperties, are ignored rather than rejected.
+// Example: LoadJSONSchema([]byte(`{"properties": {"app": {"properties": {"port": {"type": "integer"}}}}}`))
+func LoadJSONSchema(data []byte) (configContracts.ConfigSchema, error) {
+	var root jsonSchema
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("invalid json schema: %w", err)
+	}
+
+	schema := &ConfigSchema{
+		Fields: make(map[string]configContracts.ConfigSchemaField),
+	}
+	if err := addJSONSchemaFields(schema, &root, ""); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// addJSONSchemaFields adds a schema field for every property of node under prefix.
+func addJSONSchemaFields(schema *ConfigSchema, node *jsonSchema, prefix string) error {
+	required := make(map[string]bool, len(node.Required))
+	for _, name := range node.Required {
+		required[name] = true
+	}
+
+	for _, name := range sortedKeys(node.Properties) {
+		property := node.Properties[name]
+		if property == nil {
+			continue
+		}
+		path := joinKey(prefix, escapeKey(name))
+
+		if len(property.Properties) > 0 {
+			if err := addJSONSchemaFields(schema, property, path); err != nil {
+				return err
+			}
+			continue
+		}
+
+		kind, ok := jsonSchemaKind(property)
+		if !ok {
+			continue
+		}
+		field := configContracts.ConfigSchemaField{
+			Type:     kind,
+			Required: required[name],
+		}
+		if property.Pattern != "" && kind == reflect.String {
+			if _, err := regexp.Compile(property.Pattern); err != nil {
+				return fmt.Errorf("invalid pattern for '%s': %w", path, err)
+			}
+			field.Pattern = property.Pattern
+		}
+		switch kind {
+		case reflect.Slice:
+			if property.Items != nil {
+				field.ElemType, _ = jsonSchemaKind(property.Items)
+			}
+		case reflect.Map:
+			if elem, ok := property.AdditionalProperties.(map[string]interface{}); ok {
+				field.ElemType, _ = jsonSchemaKind(&jsonSchema{Type: elem["type"]})
+			}
+		}
+		if property.Default != nil {
+			def, err := jsonSchemaDefault(property.Default, kind)
+			if err != nil {
+				return fmt.Errorf("invalid default for '%s': %w", path, err)
+			}
+			field.Default = def
+		}
+		field.Min, field.Max = property.Minimum, property.Maximum
+		for _, value := range property.Enum {
+			allowed, err := jsonSchemaDefault(value, kind)
+			if err != nil {
+				return fmt.Errorf("invalid enum value for '%s': %w", path, err)
+			}
+			field.Enum = append(field.Enum, allowed)
+		}
+
+		schema.AddField(path, field)
+	}
+	return nil
+}
+
+// jsonSchemaKind returns the kind for the type of node, using the first non-null
+// type of a type list. It reports false if node has no supported type.
+func jsonSchemaKind(node *jsonSchema) (reflect.Kind, bool) {
+	switch t := node.Type.(type) {
+	case string:
+		kind, ok := jsonSchemaKinds[t]
+		return kind, ok
+	case []interface{}:
+		for _, item := range t {
+			if name, ok := item.(string); ok && name != "null" {
+				kind, ok := jsonSchemaKinds[name]
+				return kind, ok
+			}
+		}
+	}
+	return reflect.Invalid, false
+}
+
+// jsonSchemaDefault converts a default or enum value decoded from JSON to the type of its field,
+// since JSON decodes every number as float64.
+func jsonSchemaDefault(value interface{}, kind reflect.Kind) (interface{}, error) {
+	if kind != reflect.Int {
+		return value, nil
+	}
+	n, ok := value.(float64)
+	if !ok || n != float64(int(n)) {
+		return nil, fmt.Errorf("expected an integer, got %v", value)
+	}
+	return int(n), nil
+}
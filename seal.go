@@ -0,0 +1,41 @@
+package gonfig
+
+import "fmt"
+
+// Seal makes the registry immutable, for use once startup has loaded and validated
+// the configuration. Afterwards every method that changes configuration values or
+// what reads return (Register and its variants, Set, Unset, Refresh, Restore,
+// transactions, SetFallback, EnableEnvOverride, BindFlag, EnforceType, AttachSchema,
+// RegisterSchema, the array and decoding settings and the like) returns ErrSealed,
+// or is ignored and logged if it can't return an error. Reads keep working, and
+// SetLogger, SetMetricsObserver, EnableValueCache, SetReloadEnvOnRefresh and polling
+// can still be changed, since they don't affect the values read.
+// Stop polling before sealing, since every poll would be rejected. A sealed registry
+// can't be unsealed; Clone returns an unsealed copy.
+func (r *ConfigRegistry) Seal() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sealed = true
+}
+
+// IsSealed reports whether Seal has been called.
+func (r *ConfigRegistry) IsSealed() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.sealed
+}
+
+// checkSealed returns an error wrapping ErrSealed if the registry is sealed.
+// The caller must hold the lock.
+func (r *ConfigRegistry) checkSealed(target string) error {
+	if !r.sealed {
+		return nil
+	}
+	err := fmt.Errorf("%w: cannot modify '%s'", ErrSealed, target)
+	if r.logger != nil {
+		r.logger.Warn("config change rejected", "target", target, "error", err)
+	}
+	return err
+}
@@ -0,0 +1,74 @@
+package gonfig
+
+import (
+	"fmt"
+	"sort"
+
+	configContracts "github.com/centraunit/gonfig/contracts"
+)
+
+// snapshot holds a deep copy of every configuration section.
+type snapshot struct {
+	configs map[string]map[string]interface{}
+	origins map[string]map[string]string
+}
+
+// Sections returns the names of the captured sections in sorted order.
+func (s *snapshot) Sections() []string {
+	sections := make([]string, 0, len(s.configs))
+	for name := range s.configs {
+		sections = append(sections, name)
+	}
+	sort.Strings(sections)
+	return sections
+}
+
+// Snapshot captures the current configuration of every section.
+// The snapshot is a deep copy and is not affected by later changes to the registry.
+// Example: s := Snapshot(); defer Restore(s)
+func (r *ConfigRegistry) Snapshot() configContracts.Snapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return &snapshot{configs: copySections(r.configs), origins: copyOrigins(r.origins)}
+}
+
+// Restore replaces the configuration of every section with the contents of a snapshot.
+// Sections created after the snapshot was taken are removed, registered loaders are kept,
+// and structs bound with Bind are re-populated. The snapshot can be restored repeatedly.
+// Returns an error if the snapshot was not created by Snapshot.
+func (r *ConfigRegistry) Restore(s configContracts.Snapshot) error {
+	snap, ok := s.(*snapshot)
+	if !ok || snap == nil {
+		return fmt.Errorf("cannot restore snapshot of type %T", s)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkSealed("*"); err != nil {
+		return err
+	}
+	r.configs = copySections(snap.configs)
+	r.origins = copyOrigins(snap.origins)
+	r.invalidateAll()
+	for section := range r.bound {
+		if _, ok := r.configs[section]; ok {
+			_ = r.rebind(section)
+		}
+	}
+	return nil
+}
+
+// copySections returns a deep copy of a set of configuration sections.
+func copySections(configs map[string]map[string]interface{}) map[string]map[string]interface{} {
+	copied := make(map[string]map[string]interface{}, len(configs))
+	for name, config := range configs {
+		if config == nil {
+			copied[name] = nil
+			continue
+		}
+		copied[name] = deepCopy(config).(map[string]interface{})
+	}
+	return copied
+}
@@ -0,0 +1,137 @@
+package gonfig
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnmarshalStrict deserializes a configuration section into a struct like Unmarshal,
+// then checks that every key of the section was used by a struct field, which catches
+// misspelled keys that would otherwise have no effect. Nested maps decoded into struct
+// fields are checked recursively, while fields decoded as a whole, such as types
+// implementing ConfigDecoder, use everything below them. The struct is populated even
+// if unknown keys are found, and the error, which wraps ErrUnknownKey, lists their
+// full paths.
+// Example: UnmarshalStrict("database", &dbConfig)
+func (r *ConfigRegistry) UnmarshalStrict(section string, v interface{}) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err := r.unmarshal(section, v); err != nil {
+		return err
+	}
+
+	var unknown []string
+	used := usedKeys(reflect.TypeOf(v).Elem())
+	collectUnknownKeys(r.configs[section], used, section, &unknown)
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return newPathError(ErrUnknownKey, section, section, "unknown config keys in section '%s': %s", section, strings.Join(unknown, ", "))
+}
+
+// keyTree records the config keys used by a struct. A key maps to nil if the field
+// uses everything below it, or to the keys used by its nested struct.
+type keyTree map[string]keyTree
+
+// usedKeys returns the config keys used by the fields of a struct type, following
+// the same rules as Unmarshal.
+func usedKeys(typ reflect.Type) keyTree {
+	used := make(keyTree)
+	addUsedKeys(used, typ)
+	return used
+}
+
+// addUsedKeys adds the keys used by the fields of typ to used.
+func addUsedKeys(used keyTree, typ reflect.Type) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		// Embedded structs without a tag read their fields from the same level
+		if field.Anonymous && field.Tag.Get("config") == "" {
+			if embedded := derefType(field.Type); embedded.Kind() == reflect.Struct {
+				addUsedKeys(used, embedded)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		key := field.Tag.Get("config")
+		if key == "" {
+			key = strings.ToLower(field.Name)
+		}
+		if key == "-" {
+			continue
+		}
+
+		// Keys can be paths into nested maps, such as "credentials.username"
+		node, last, ok := used.parent(splitPath(key))
+		if !ok {
+			continue
+		}
+		if existing, ok := node[last]; ok && existing == nil {
+			continue
+		}
+		if nested := derefType(field.Type); nested.Kind() == reflect.Struct && !decodesItself(nested) {
+			subtree, ok := node[last]
+			if !ok {
+				subtree = make(keyTree)
+				node[last] = subtree
+			}
+			addUsedKeys(subtree, nested)
+			continue
+		}
+		node[last] = nil
+	}
+}
+
+// parent returns the tree holding the last of a split key, creating the trees of its
+// parents, and the last part. It reports false if a parent is already used entirely.
+func (t keyTree) parent(parts []string) (keyTree, string, bool) {
+	node := t
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := node[part]
+		if ok && next == nil {
+			return nil, "", false
+		}
+		if !ok {
+			next = make(keyTree)
+			node[part] = next
+		}
+		node = next
+	}
+	return node, parts[len(parts)-1], true
+}
+
+// collectUnknownKeys appends the full path of every key of config that isn't in used.
+func collectUnknownKeys(config map[string]interface{}, used keyTree, path string, unknown *[]string) {
+	for key, value := range config {
+		keyPath := joinKey(path, escapeKey(key))
+		subtree, ok := used[key]
+		if !ok {
+			*unknown = append(*unknown, keyPath)
+			continue
+		}
+		if nested, isMap := value.(map[string]interface{}); isMap && subtree != nil {
+			collectUnknownKeys(nested, subtree, keyPath, unknown)
+		}
+	}
+}
+
+// derefType returns the element type of a pointer type, or the type itself.
+func derefType(typ reflect.Type) reflect.Type {
+	if typ.Kind() == reflect.Ptr {
+		return typ.Elem()
+	}
+	return typ
+}
+
+// decodesItself reports whether a struct type is decoded as a whole rather than field
+// by field, because it implements ConfigDecoder or is converted like time.Time.
+func decodesItself(typ reflect.Type) bool {
+	return reflect.PointerTo(typ).Implements(decoderType) || typ == timeType
+}
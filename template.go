@@ -0,0 +1,230 @@
+package gonfig
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// templateValue is a string value of a template section that contains a template.
+type templateValue struct {
+	tmpl *template.Template
+	set  func(rendered string)
+
+	// Paths referenced by the template, such as "app.database.host"
+	deps []string
+}
+
+// RegisterTemplateSection marks a section whose string values are Go templates,
+// rendered each time the section is loaded against the whole configuration, so
+// `{{ .database.host }}` reads the host of the database section. Templates may
+// reference other values of the same section, which are rendered first; a cycle
+// between them is an error. Other sections are read as currently loaded, so they
+// should be registered first, and Refresh reloads template sections last.
+// The current contents of the section, if any, are rendered immediately.
+// Example: RegisterTemplateSection("app") with "dsn": "{{ .database.host }}:{{ .database.port }}"
+func (r *ConfigRegistry) RegisterTemplateSection(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkSealed(name); err != nil {
+		return err
+	}
+	if r.templates == nil {
+		r.templates = make(map[string]bool)
+	}
+	r.templates[name] = true
+
+	config, ok := r.configs[name]
+	if !ok {
+		return nil
+	}
+	rendered, err := r.renderTemplates(name, config)
+	if err != nil {
+		return err
+	}
+	r.store(name, rendered)
+	return nil
+}
+
+// renderTemplates returns a copy of config, the freshly loaded contents of section,
+// with every template it contains rendered. Templates in the section are rendered
+// after the ones they reference.
+// The caller must hold the write lock.
+func (r *ConfigRegistry) renderTemplates(section string, config map[string]interface{}) (map[string]interface{}, error) {
+	config, _ = deepCopy(config).(map[string]interface{})
+	values := make(map[string]*templateValue)
+	if err := collectTemplates(config, escapeKey(section), values); err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return config, nil
+	}
+
+	data := make(map[string]interface{}, len(r.configs)+1)
+	for name, sectionConfig := range r.configs {
+		data[name] = sectionConfig
+	}
+	data[section] = config
+
+	rendered := make(map[string]bool, len(values))
+	var render func(path string, chain []string) error
+	render = func(path string, chain []string) error {
+		if rendered[path] {
+			return nil
+		}
+		for i, visiting := range chain {
+			if visiting == path {
+				return fmt.Errorf("template cycle: %s", strings.Join(append(chain[i:], path), " -> "))
+			}
+		}
+		chain = append(chain, path)
+
+		value := values[path]
+		for _, dep := range sortedKeys(values) {
+			if dep != path && referencesPath(value.deps, dep) {
+				if err := render(dep, chain); err != nil {
+					return err
+				}
+			}
+		}
+
+		var out bytes.Buffer
+		if err := value.tmpl.Execute(&out, data); err != nil {
+			return fmt.Errorf("failed to render template at '%s': %w", path, err)
+		}
+		value.set(out.String())
+		rendered[path] = true
+		return nil
+	}
+
+	for _, path := range sortedKeys(values) {
+		if err := render(path, nil); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
+
+// collectTemplates adds every string in value that contains a template action to values,
+// keyed by its full path, along with a setter that replaces it in place.
+func collectTemplates(value interface{}, path string, values map[string]*templateValue) error {
+	add := func(path, text string, set func(string)) error {
+		if !strings.Contains(text, "{{") {
+			return nil
+		}
+		tmpl, err := template.New(path).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return fmt.Errorf("invalid template at '%s': %w", path, err)
+		}
+		value := &templateValue{tmpl: tmpl, set: set}
+		for _, t := range tmpl.Templates() {
+			if t.Tree != nil {
+				value.deps = append(value.deps, templateFields(t.Root)...)
+			}
+		}
+		values[path] = value
+		return nil
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			itemPath := joinKey(path, escapeKey(key))
+			if s, ok := item.(string); ok {
+				if err := add(itemPath, s, func(rendered string) { v[key] = rendered }); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := collectTemplates(item, itemPath, values); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			itemPath := joinKey(path, strconv.Itoa(i))
+			if s, ok := item.(string); ok {
+				if err := add(itemPath, s, func(rendered string) { v[i] = rendered }); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := collectTemplates(item, itemPath, values); err != nil {
+				return err
+			}
+		}
+	case []string:
+		for i, item := range v {
+			if err := add(joinKey(path, strconv.Itoa(i)), item, func(rendered string) { v[i] = rendered }); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// templateFields returns the paths of the fields referenced by a template tree,
+// such as "database.host" for `{{ .database.host }}`.
+func templateFields(node parse.Node) []string {
+	var fields []string
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, cmd := range n.Cmds {
+				walk(cmd)
+			}
+		case *parse.CommandNode:
+			for _, arg := range n.Args {
+				walk(arg)
+			}
+		case *parse.FieldNode:
+			fields = append(fields, joinParts(n.Ident))
+		case *parse.ChainNode:
+			walk(n.Node)
+		case *parse.IfNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.TemplateNode:
+			walk(n.Pipe)
+		}
+	}
+	walk(node)
+	return fields
+}
+
+// referencesPath reports whether any of fields reads the value at path, either
+// directly or through a map that contains it.
+func referencesPath(fields []string, path string) bool {
+	for _, field := range fields {
+		if field == path || strings.HasPrefix(path, field+".") || strings.HasPrefix(field, path+".") {
+			return true
+		}
+	}
+	return false
+}
@@ -1,13 +1,27 @@
 package config_test
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"log/slog"
+	"math"
+	"net"
 	"os"
+	"path/filepath"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/centraunit/gonfig"
 	configContracts "github.com/centraunit/gonfig/contracts"
+	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -106,6 +120,17 @@ func (suite *ConfigTestSuite) TestGetBool() {
 	suite.NoError(err)
 	suite.Equal(true, value)
 
+	// Test integers and numeric strings, as some loaders produce them for flags
+	value, err = suite.registry.GetBool("test.int_value")
+	suite.NoError(err)
+	suite.Equal(true, value)
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+	registry.RegisterMap("flags", map[string]interface{}{"off": 0, "on": "1", "zero": "0"})
+	suite.False(registry.MustGetBool("flags.off"))
+	suite.True(registry.MustGetBool("flags.on"))
+	suite.False(registry.MustGetBool("flags.zero"))
+
 	// Test invalid value
 	_, err = suite.registry.GetBool("test.string_value")
 	suite.Error(err)
@@ -273,9 +298,9 @@ func (suite *ConfigTestSuite) TestErrorHandling() {
 	suite.Error(err)
 	suite.Contains(err.Error(), "cannot convert value 'test' at path 'test.string_value' to int")
 
-	_, err = suite.registry.GetBool("test.int_value")
+	_, err = suite.registry.GetBool("test.float_value")
 	suite.Error(err)
-	suite.Contains(err.Error(), "cannot convert value at path 'test.int_value' to bool: found type int")
+	suite.Contains(err.Error(), "cannot convert value at path 'test.float_value' to bool: found type float64")
 
 	_, err = suite.registry.GetFloat("test.bool_value")
 	suite.Error(err)
@@ -294,7 +319,7 @@ func (suite *ConfigTestSuite) TestErrorHandling() {
 	})
 	_, err = suite.registry.GetStringArray("test_arrays.mixed_array")
 	suite.Error(err)
-	suite.Contains(err.Error(), "cannot convert item at index 1 in path 'test_arrays.mixed_array' to string: found type int")
+	suite.Contains(err.Error(), "cannot convert item at index 2 in path 'test_arrays.mixed_array' to string: found type bool")
 }
 
 // TestDefaultValues tests default value handling
@@ -591,3 +616,3940 @@ func (suite *ConfigTestSuite) TestSingletonBehavior() {
 	suite.NoError(err)
 	suite.Equal("changed", value)
 }
+
+// TestMergeFrom tests deep-merging external sections into the registry
+func (suite *ConfigTestSuite) TestMergeFrom() {
+	suite.registry.MergeFrom(map[string]map[string]interface{}{
+		"test": {
+			"string_value": "merged",
+			"nested": map[string]interface{}{
+				"key": "merged_value",
+			},
+		},
+		"merged": {
+			"value": "new_section",
+		},
+	})
+
+	// Existing value should be overridden
+	value, err := suite.registry.GetString("test.string_value")
+	suite.NoError(err)
+	suite.Equal("merged", value)
+
+	// Nested value should be overridden while siblings are preserved
+	value, err = suite.registry.GetString("test.nested.key")
+	suite.NoError(err)
+	suite.Equal("merged_value", value)
+
+	value, err = suite.registry.GetString("test.nested.deep.deeper.deepest")
+	suite.NoError(err)
+	suite.Equal("found", value)
+
+	// Untouched values should remain
+	intVal, err := suite.registry.GetInt("test.int_value")
+	suite.NoError(err)
+	suite.Equal(42, intVal)
+
+	// New section should be created
+	value, err = suite.registry.GetString("merged.value")
+	suite.NoError(err)
+	suite.Equal("new_section", value)
+
+	// Merged slices should be copied
+	list := []interface{}{"a", "b"}
+	suite.registry.MergeFrom(map[string]map[string]interface{}{"merged": {"list": list}})
+	list[0] = "changed"
+	items, err := suite.registry.GetStringArray("merged.list")
+	suite.NoError(err)
+	suite.Equal([]string{"a", "b"}, items)
+}
+
+// TestArrayIndexPaths tests addressing array elements with numeric path segments
+func (suite *ConfigTestSuite) TestArrayIndexPaths() {
+	suite.registry.Register("cluster", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{
+			"servers": []interface{}{
+				map[string]interface{}{"host": "alpha", "port": 8001},
+				map[string]interface{}{"host": "beta", "port": 8002},
+			},
+			"tags": []string{"one", "two", "three"},
+		}
+	})
+
+	// Test reading through an array of maps
+	value, err := suite.registry.GetString("cluster.servers.1.host")
+	suite.NoError(err)
+	suite.Equal("beta", value)
+
+	// Test reading a string array element
+	value, err = suite.registry.GetString("cluster.tags.2")
+	suite.NoError(err)
+	suite.Equal("three", value)
+
+	// Test out of range index
+	_, err = suite.registry.Get("cluster.tags.5")
+	suite.Error(err)
+	suite.Contains(err.Error(), "array index out of range: 'tags.5' in path 'cluster.tags.5'")
+
+	// Test non-numeric index
+	_, err = suite.registry.Get("cluster.servers.first.host")
+	suite.Error(err)
+	suite.Contains(err.Error(), "invalid array index: 'servers.first' in path 'cluster.servers.first.host'")
+
+	// Test setting values through array indexes
+	err = suite.registry.Set("cluster.servers.0.host", "gamma")
+	suite.NoError(err)
+	value, err = suite.registry.GetString("cluster.servers.0.host")
+	suite.NoError(err)
+	suite.Equal("gamma", value)
+
+	err = suite.registry.Set("cluster.tags.0", "zero")
+	suite.NoError(err)
+	value, err = suite.registry.GetString("cluster.tags.0")
+	suite.NoError(err)
+	suite.Equal("zero", value)
+
+	// Test setting an incompatible element type
+	err = suite.registry.Set("cluster.tags.0", 42)
+	suite.Error(err)
+
+	// Test setting out of range
+	err = suite.registry.Set("cluster.tags.9", "nine")
+	suite.Error(err)
+	suite.Contains(err.Error(), "array index out of range")
+}
+
+// TestEscapedDotKeys tests addressing keys that contain dots
+func (suite *ConfigTestSuite) TestEscapedDotKeys() {
+	suite.registry.Register("hosts", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{
+			"api.example.com": map[string]interface{}{
+				"port": 443,
+			},
+		}
+	})
+
+	// Test backslash-escaped form
+	value, err := suite.registry.GetInt("hosts.api\\.example\\.com.port")
+	suite.NoError(err)
+	suite.Equal(443, value)
+
+	// Test bracket form
+	value, err = suite.registry.GetInt(`hosts["api.example.com"].port`)
+	suite.NoError(err)
+	suite.Equal(443, value)
+
+	// Test setting through the escaped form
+	err = suite.registry.Set("hosts.api\\.example\\.com.port", 8443)
+	suite.NoError(err)
+	value, err = suite.registry.GetInt(`hosts["api.example.com"].port`)
+	suite.NoError(err)
+	suite.Equal(8443, value)
+
+	// Unescaped dots still split the key
+	_, err = suite.registry.Get("hosts.api.example.com.port")
+	suite.Error(err)
+}
+
+// TestGetOrSet tests lazily initializing a configuration value
+func (suite *ConfigTestSuite) TestGetOrSet() {
+	// Test existing value is returned unchanged
+	value, err := suite.registry.GetOrSet("test.string_value", "ignored")
+	suite.NoError(err)
+	suite.Equal("test", value)
+
+	// Test missing value is set and returned
+	value, err = suite.registry.GetOrSet("test.lazy_value", "initialized")
+	suite.NoError(err)
+	suite.Equal("initialized", value)
+
+	str, err := suite.registry.GetString("test.lazy_value")
+	suite.NoError(err)
+	suite.Equal("initialized", str)
+
+	// Test subsequent call returns the stored value
+	value, err = suite.registry.GetOrSet("test.lazy_value", "other")
+	suite.NoError(err)
+	suite.Equal("initialized", value)
+
+	// Test invalid path
+	_, err = suite.registry.GetOrSet("invalid", "value")
+	suite.Error(err)
+	suite.Contains(err.Error(), "invalid config path")
+
+	// Test nonexistent section
+	_, err = suite.registry.GetOrSet("nonexistent.key", "value")
+	suite.Error(err)
+	suite.Contains(err.Error(), "config section not found")
+
+	// Test lookup errors other than a missing value are returned without setting
+	_, err = suite.registry.GetOrSet("test.string_value.host", "h")
+	suite.ErrorIs(err, gonfig.ErrInvalidPath)
+	suite.Equal("test", suite.registry.MustGetString("test.string_value"))
+
+	suite.registry.EnforceType("test.string_value", reflect.Int)
+	defer suite.registry.EnforceType("test.string_value", reflect.Invalid)
+	_, err = suite.registry.GetOrSet("test.string_value", 42)
+	suite.ErrorIs(err, gonfig.ErrTypeConversion)
+	suite.registry.EnforceType("test.string_value", reflect.Invalid)
+	suite.Equal("test", suite.registry.MustGetString("test.string_value"))
+
+	// Test transactions apply the same rule
+	err = suite.registry.Transaction(func(tx configContracts.ConfigRegistry) error {
+		_, err := tx.GetOrSet("test.string_value.host", "h")
+		return err
+	})
+	suite.ErrorIs(err, gonfig.ErrInvalidPath)
+}
+
+// TestAttachSchema tests validating writes against an attached schema
+func (suite *ConfigTestSuite) TestAttachSchema() {
+	schema := gonfig.NewConfigSchema()
+	schema.AddField("test.port", configContracts.ConfigSchemaField{
+		Type: reflect.Int,
+		Validator: func(v interface{}) error {
+			if port := v.(int); port < 1 || port > 65535 {
+				return fmt.Errorf("port must be between 1 and 65535")
+			}
+			return nil
+		},
+	})
+	suite.registry.AttachSchema(schema)
+	defer suite.registry.AttachSchema(nil)
+
+	// Test valid write
+	err := suite.registry.SetInt("test.port", 8080)
+	suite.NoError(err)
+	port, err := suite.registry.GetInt("test.port")
+	suite.NoError(err)
+	suite.Equal(8080, port)
+
+	// Test wrong type is rejected and the value is unchanged
+	err = suite.registry.SetString("test.port", "8081")
+	suite.Error(err)
+	suite.Contains(err.Error(), "validation failed for test.port: expected type int")
+
+	// Test validator failure is rejected
+	err = suite.registry.Set("test.port", 70000)
+	suite.Error(err)
+	suite.Contains(err.Error(), "port must be between 1 and 65535")
+
+	port, err = suite.registry.GetInt("test.port")
+	suite.NoError(err)
+	suite.Equal(8080, port)
+
+	// Test paths without a schema field pass through
+	err = suite.registry.SetBool("test.unschemed", true)
+	suite.NoError(err)
+	err = suite.registry.SetFloat("test.unschemed_float", 1.5)
+	suite.NoError(err)
+}
+
+// TestSchemaElemType tests element kind validation for slice and map fields
+func (suite *ConfigTestSuite) TestSchemaElemType() {
+	schema := gonfig.NewConfigSchema()
+	schema.AddField("cors.allowed_origins", configContracts.ConfigSchemaField{
+		Type:     reflect.Slice,
+		ElemType: reflect.String,
+		Required: true,
+	})
+	schema.AddField("cors.limits", configContracts.ConfigSchemaField{
+		Type:     reflect.Map,
+		ElemType: reflect.Int,
+	})
+
+	// Test valid typed and untyped slices
+	err := schema.Validate(map[string]interface{}{
+		"cors": map[string]interface{}{
+			"allowed_origins": []string{"https://example.com"},
+			"limits":          map[string]interface{}{"max_age": 600},
+		},
+	})
+	suite.NoError(err)
+
+	err = schema.Validate(map[string]interface{}{
+		"cors": map[string]interface{}{
+			"allowed_origins": []interface{}{"https://example.com", "https://example.org"},
+		},
+	})
+	suite.NoError(err)
+
+	// Test wrong slice element kind
+	err = schema.Validate(map[string]interface{}{
+		"cors": map[string]interface{}{
+			"allowed_origins": []interface{}{"https://example.com", 42},
+		},
+	})
+	suite.Error(err)
+	suite.Contains(err.Error(), "validation failed for cors.allowed_origins: expected element type string at index 1, got int")
+
+	// Test wrong map value kind
+	err = schema.Validate(map[string]interface{}{
+		"cors": map[string]interface{}{
+			"allowed_origins": []string{},
+			"limits":          map[string]interface{}{"max_age": "600"},
+		},
+	})
+	suite.Error(err)
+	suite.Contains(err.Error(), "expected element type int for key 'max_age', got string")
+}
+
+// TestSchemaValidateRegistry tests validating the live registry contents
+func (suite *ConfigTestSuite) TestSchemaValidateRegistry() {
+	schema := gonfig.NewConfigSchema()
+	schema.AddField("test.string_value", configContracts.ConfigSchemaField{
+		Type:     reflect.String,
+		Required: true,
+	})
+	schema.AddField("test.int_value", configContracts.ConfigSchemaField{
+		Type:     reflect.Int,
+		Required: true,
+	})
+	schema.AddField("test.registry_default", configContracts.ConfigSchemaField{
+		Type:    reflect.String,
+		Default: "filled",
+	})
+
+	// Test valid registry
+	err := schema.ValidateRegistry(suite.registry)
+	suite.NoError(err)
+
+	// Verify default value was set in the registry
+	value, err := suite.registry.GetString("test.registry_default")
+	suite.NoError(err)
+	suite.Equal("filled", value)
+
+	// Test all failures are reported together
+	schema.AddField("test.bool_value", configContracts.ConfigSchemaField{
+		Type: reflect.String,
+	})
+	schema.AddField("test.missing_value", configContracts.ConfigSchemaField{
+		Type:     reflect.String,
+		Required: true,
+	})
+	err = schema.ValidateRegistry(suite.registry)
+	suite.Error(err)
+	suite.Contains(err.Error(), "validation failed for test.bool_value: expected type string, got bool")
+	suite.Contains(err.Error(), "required field missing: test.missing_value")
+}
+
+// TestSchemaCollectsErrors tests that validation reports every failing field
+func (suite *ConfigTestSuite) TestSchemaCollectsErrors() {
+	schema := gonfig.NewConfigSchema()
+	schema.AddField("test.name", configContracts.ConfigSchemaField{
+		Type:     reflect.String,
+		Required: true,
+	})
+	schema.AddField("test.port", configContracts.ConfigSchemaField{
+		Type: reflect.Int,
+	})
+	schema.AddField("test.ratio", configContracts.ConfigSchemaField{
+		Type: reflect.Float64,
+		Validator: func(v interface{}) error {
+			return fmt.Errorf("ratio is never valid")
+		},
+	})
+
+	invalidConfig := map[string]interface{}{
+		"test": map[string]interface{}{
+			"port":  "8080",
+			"ratio": 0.5,
+		},
+	}
+
+	// Test all failures are collected
+	err := schema.Validate(invalidConfig)
+	suite.Error(err)
+	suite.Contains(err.Error(), "required field missing: test.name")
+	suite.Contains(err.Error(), "validation failed for test.port: expected type int, got string")
+	suite.Contains(err.Error(), "validation failed for test.ratio: ratio is never valid")
+
+	// Test fail-fast mode stops at the first failure
+	schema.SetFailFast(true)
+	err = schema.Validate(invalidConfig)
+	suite.Error(err)
+	suite.Equal("required field missing: test.name", err.Error())
+}
+
+// TestSchemaRules tests cross-field validation rules
+func (suite *ConfigTestSuite) TestSchemaRules() {
+	schema := gonfig.NewConfigSchema()
+	schema.AddField("tls.enabled", configContracts.ConfigSchemaField{
+		Type:     reflect.Bool,
+		Required: true,
+	})
+	schema.AddRule(func(config map[string]interface{}) error {
+		tls, _ := config["tls"].(map[string]interface{})
+		if enabled, _ := tls["enabled"].(bool); enabled {
+			if _, ok := tls["cert_file"]; !ok {
+				return fmt.Errorf("tls.cert_file is required when tls.enabled is true")
+			}
+		}
+		return nil
+	})
+
+	// Test rule passes
+	err := schema.Validate(map[string]interface{}{
+		"tls": map[string]interface{}{"enabled": true, "cert_file": "/etc/cert.pem"},
+	})
+	suite.NoError(err)
+
+	err = schema.Validate(map[string]interface{}{
+		"tls": map[string]interface{}{"enabled": false},
+	})
+	suite.NoError(err)
+
+	// Test rule failure
+	err = schema.Validate(map[string]interface{}{
+		"tls": map[string]interface{}{"enabled": true},
+	})
+	suite.Error(err)
+	suite.Contains(err.Error(), "tls.cert_file is required when tls.enabled is true")
+
+	// Test rule errors are aggregated with field errors
+	err = schema.Validate(map[string]interface{}{
+		"tls": map[string]interface{}{"enabled": "yes"},
+	})
+	suite.Error(err)
+	suite.Contains(err.Error(), "validation failed for tls.enabled: expected type bool")
+
+	// Test rules run against the registry
+	suite.registry.Register("tls", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{"enabled": true}
+	})
+	err = schema.ValidateRegistry(suite.registry)
+	suite.Error(err)
+	suite.Contains(err.Error(), "tls.cert_file is required when tls.enabled is true")
+}
+
+// TestSchemaFromStruct tests generating a schema from struct tags
+func (suite *ConfigTestSuite) TestSchemaFromStruct() {
+	type ServerConfig struct {
+		Host    string   `config:"host" required:"true"`
+		Port    int      `config:"port" default:"8080"`
+		Debug   bool     `config:"debug" default:"false"`
+		Origins []string `config:"origins"`
+		Ignored string   `config:"-"`
+	}
+	type AppConfig struct {
+		Server ServerConfig `config:"server"`
+	}
+
+	schema, err := gonfig.SchemaFromStruct(&AppConfig{})
+	suite.NoError(err)
+
+	// Test defaults are applied and types checked
+	config := map[string]interface{}{
+		"server": map[string]interface{}{
+			"host":    "localhost",
+			"origins": []string{"https://example.com"},
+		},
+	}
+	err = schema.Validate(config)
+	suite.NoError(err)
+	suite.Equal(8080, config["server"].(map[string]interface{})["port"])
+	suite.Equal(false, config["server"].(map[string]interface{})["debug"])
+
+	// Test required field and element type
+	err = schema.Validate(map[string]interface{}{
+		"server": map[string]interface{}{
+			"origins": []interface{}{1, 2},
+		},
+	})
+	suite.Error(err)
+	suite.Contains(err.Error(), "required field missing: server.host")
+	suite.Contains(err.Error(), "validation failed for server.origins: expected element type string at index 0, got int")
+
+	// Test invalid sources
+	_, err = gonfig.SchemaFromStruct("not a struct")
+	suite.Error(err)
+
+	type BadDefault struct {
+		Port int `config:"port" default:"abc"`
+	}
+	_, err = gonfig.SchemaFromStruct(BadDefault{})
+	suite.Error(err)
+	suite.Contains(err.Error(), "invalid default for 'port'")
+
+	// Test fields are described as Unmarshal reads them
+	type Common struct {
+		Name string `config:"name" required:"true"`
+	}
+	type TLSConfig struct {
+		Cert string `config:"cert" required:"true"`
+	}
+	type ServiceConfig struct {
+		Common
+		TLS     *TLSConfig `config:"tls"`
+		Started time.Time  `config:"started" timeformat:"2006-01-02"`
+	}
+	type Services struct {
+		Service ServiceConfig `config:"service"`
+	}
+	schema, err = gonfig.SchemaFromStruct(Services{})
+	suite.NoError(err)
+
+	err = schema.Validate(map[string]interface{}{
+		"service": map[string]interface{}{
+			"name":    "api",
+			"tls":     map[string]interface{}{"cert": "cert.pem"},
+			"started": "2024-01-02",
+		},
+	})
+	suite.NoError(err)
+
+	err = schema.Validate(map[string]interface{}{
+		"service": map[string]interface{}{
+			"started": "yesterday",
+		},
+	})
+	suite.Error(err)
+	suite.Contains(err.Error(), "required field missing: service.name")
+	suite.Contains(err.Error(), "required field missing: service.tls.cert")
+	suite.Contains(err.Error(), "validation failed for service.started")
+	suite.NoError(schema.ValidateField("service.started", time.Now()))
+
+	// Test a JSON config that Unmarshal accepts also passes the generated schema
+	type HTTPSettings struct {
+		Port    int     `config:"port" required:"true"`
+		Ratio   float64 `config:"ratio"`
+		Retries uint    `config:"retries"`
+	}
+	type Document struct {
+		HTTP HTTPSettings `config:"http"`
+	}
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+	suite.NoError(registry.RegisterReader("http", "json", strings.NewReader(`{"port": 8080, "ratio": 1, "retries": 3}`)))
+	schema, err = gonfig.SchemaFromStruct(Document{})
+	suite.NoError(err)
+	suite.NoError(schema.ValidateRegistry(registry))
+
+	var doc Document
+	suite.NoError(registry.Unmarshal("http", &doc.HTTP))
+	suite.Equal(8080, doc.HTTP.Port)
+
+	// Test strings read from the environment are accepted as Unmarshal converts them
+	suite.NoError(registry.Set("http.port", "9090"))
+	suite.NoError(registry.Set("http.ratio", "0.5"))
+	suite.NoError(schema.ValidateRegistry(registry))
+	suite.NoError(registry.Unmarshal("http", &doc.HTTP))
+	suite.Equal(9090, doc.HTTP.Port)
+
+	suite.NoError(registry.Set("http.port", "eighty"))
+	suite.NoError(registry.Set("http.retries", -1))
+	err = schema.ValidateRegistry(registry)
+	suite.Error(err)
+	suite.Contains(err.Error(), "validation failed for http.port: invalid integer")
+	suite.Contains(err.Error(), "validation failed for http.retries: expected type uint, got int64")
+}
+
+// TestErrorCategories tests that errors match their sentinel categories
+func (suite *ConfigTestSuite) TestErrorCategories() {
+	_, err := suite.registry.Get("nonexistent.key")
+	suite.ErrorIs(err, gonfig.ErrSectionNotFound)
+	suite.Contains(err.Error(), "config section not found: 'nonexistent' in path 'nonexistent.key'")
+
+	_, err = suite.registry.Get("test.nonexistent")
+	suite.ErrorIs(err, gonfig.ErrKeyNotFound)
+	suite.NotErrorIs(err, gonfig.ErrSectionNotFound)
+
+	_, err = suite.registry.GetInt("test.string_value")
+	suite.ErrorIs(err, gonfig.ErrTypeConversion)
+	suite.Contains(err.Error(), "cannot convert value 'test' at path 'test.string_value' to int")
+
+	err = suite.registry.Set("invalid", "value")
+	suite.ErrorIs(err, gonfig.ErrInvalidPath)
+
+	err = suite.registry.Set("nonexistent.key", "value")
+	suite.ErrorIs(err, gonfig.ErrSectionNotFound)
+
+	var target struct {
+		Value int `config:"value"`
+	}
+	err = suite.registry.Unmarshal("testget", &target)
+	suite.ErrorIs(err, gonfig.ErrTypeConversion)
+}
+
+// TestPathError tests the structured error details returned by accessors
+func (suite *ConfigTestSuite) TestPathError() {
+	var pathErr *gonfig.PathError
+
+	// Test missing key details
+	_, err := suite.registry.Get("test.nested.missing.key")
+	suite.True(errors.As(err, &pathErr))
+	suite.Equal("test.nested.missing.key", pathErr.Path)
+	suite.Equal("nested.missing", pathErr.Segment)
+	suite.Equal(gonfig.ErrKeyNotFound, pathErr.Kind)
+	suite.Equal("key not found: 'nested.missing' in path 'test.nested.missing.key'", pathErr.Error())
+
+	// Test missing section details
+	_, err = suite.registry.GetString("nonexistent.key")
+	suite.True(errors.As(err, &pathErr))
+	suite.Equal("nonexistent", pathErr.Segment)
+	suite.Equal(gonfig.ErrSectionNotFound, pathErr.Kind)
+
+	// Test conversion details
+	_, err = suite.registry.GetBool("test.float_value")
+	suite.True(errors.As(err, &pathErr))
+	suite.Equal("test.float_value", pathErr.Path)
+	suite.Equal("bool", pathErr.Expected)
+	suite.Equal("float64", pathErr.Actual)
+	suite.Equal("cannot convert value at path 'test.float_value' to bool: found type float64", pathErr.Error())
+
+	// Test underlying cause is preserved
+	_, err = suite.registry.GetInt("test.string_value")
+	suite.True(errors.As(err, &pathErr))
+	suite.ErrorIs(err, strconv.ErrSyntax)
+	suite.Equal("int", pathErr.Expected)
+	suite.Equal("string", pathErr.Actual)
+}
+
+// TestGetContext tests context-aware lookups
+func (suite *ConfigTestSuite) TestGetContext() {
+	// Test lookup with a live context
+	value, err := suite.registry.GetContext(context.Background(), "test.string_value")
+	suite.NoError(err)
+	suite.Equal("test", value)
+
+	// Test lookup errors are returned as with Get
+	_, err = suite.registry.GetContext(context.Background(), "test.nonexistent")
+	suite.ErrorIs(err, gonfig.ErrKeyNotFound)
+
+	// Test cancelled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = suite.registry.GetContext(ctx, "test.string_value")
+	suite.ErrorIs(err, context.Canceled)
+}
+
+// TestBindFlag tests command-line flags overriding configuration values
+func (suite *ConfigTestSuite) TestBindFlag() {
+	suite.registry.Register("flags", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{
+			"port": 8080,
+			"name": "loader",
+		}
+	})
+
+	flag.Int("gonfig-test-port", 8080, "test port")
+	suite.registry.BindFlag("flags.port", flag.Lookup("gonfig-test-port"))
+
+	// Test loader value is used while the flag is not set
+	port, err := suite.registry.GetInt("flags.port")
+	suite.NoError(err)
+	suite.Equal(8080, port)
+	suite.NoError(suite.registry.Set("flags.port", 7070))
+	port, err = suite.registry.GetInt("flags.port")
+	suite.NoError(err)
+	suite.Equal(7070, port)
+
+	// Test explicitly set flag wins, even when set to its default value
+	suite.NoError(flag.Set("gonfig-test-port", "8080"))
+	port, err = suite.registry.GetInt("flags.port")
+	suite.NoError(err)
+	suite.Equal(8080, port)
+
+	// Test pflag binding
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("name", "default", "test name")
+	suite.registry.(*gonfig.ConfigRegistry).BindPFlag("flags.name", flags.Lookup("name"))
+
+	name, err := suite.registry.GetString("flags.name")
+	suite.NoError(err)
+	suite.Equal("loader", name)
+
+	suite.NoError(flags.Parse([]string{"--name=cli"}))
+	name, err = suite.registry.GetString("flags.name")
+	suite.NoError(err)
+	suite.Equal("cli", name)
+
+	// Test pflag slice flags read as arrays
+	flags.StringSlice("hosts", nil, "test hosts")
+	suite.registry.(*gonfig.ConfigRegistry).BindPFlag("flags.hosts", flags.Lookup("hosts"))
+	suite.NoError(flags.Parse([]string{"--hosts=a,b"}))
+	hosts, err := suite.registry.GetStringArray("flags.hosts")
+	suite.NoError(err)
+	suite.Equal([]string{"a", "b"}, hosts)
+}
+
+// TestUnset tests removing configuration values
+func (suite *ConfigTestSuite) TestUnset() {
+	// Test removing a nested value keeps siblings
+	err := suite.registry.Unset("test.nested.key")
+	suite.NoError(err)
+	_, err = suite.registry.Get("test.nested.key")
+	suite.ErrorIs(err, gonfig.ErrKeyNotFound)
+
+	value, err := suite.registry.GetString("test.nested.deep.deeper.deepest")
+	suite.NoError(err)
+	suite.Equal("found", value)
+
+	// Test removing a top-level value
+	err = suite.registry.Unset("test.string_value")
+	suite.NoError(err)
+	_, err = suite.registry.Get("test.string_value")
+	suite.Error(err)
+
+	// Test error cases
+	suite.ErrorIs(suite.registry.Unset("test.nonexistent"), gonfig.ErrKeyNotFound)
+	suite.ErrorIs(suite.registry.Unset("nonexistent.key"), gonfig.ErrSectionNotFound)
+	suite.ErrorIs(suite.registry.Unset("invalid"), gonfig.ErrInvalidPath)
+}
+
+// TestReadOnly tests the read-only registry view
+func (suite *ConfigTestSuite) TestReadOnly() {
+	readOnly := suite.registry.ReadOnly()
+
+	// Test reads work normally
+	value, err := readOnly.GetString("test.string_value")
+	suite.NoError(err)
+	suite.Equal("test", value)
+
+	// Test mutations are rejected
+	err = readOnly.Set("test.string_value", "changed")
+	suite.ErrorIs(err, gonfig.ErrReadOnly)
+	suite.ErrorIs(readOnly.SetInt("test.int_value", 1), gonfig.ErrReadOnly)
+	suite.ErrorIs(readOnly.Unset("test.string_value"), gonfig.ErrReadOnly)
+
+	_, err = readOnly.GetOrSet("test.new_key", "value")
+	suite.ErrorIs(err, gonfig.ErrReadOnly)
+
+	readOnly.Register("test", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{"string_value": "replaced"}
+	})
+	suite.ErrorIs(readOnly.Refresh(), gonfig.ErrReadOnly)
+
+	value, err = suite.registry.GetString("test.string_value")
+	suite.NoError(err)
+	suite.Equal("test", value)
+
+	// Test updates through the original registry are visible
+	err = suite.registry.Set("test.string_value", "updated")
+	suite.NoError(err)
+	value, err = readOnly.GetString("test.string_value")
+	suite.NoError(err)
+	suite.Equal("updated", value)
+}
+
+// TestRegisterE tests that loader errors and panics are reported
+func (suite *ConfigTestSuite) TestRegisterE() {
+	// Test successful registration
+	err := suite.registry.RegisterE("test_register_e", func(registry configContracts.ConfigRegistry) (map[string]interface{}, error) {
+		return map[string]interface{}{"value": "ok"}, nil
+	})
+	suite.NoError(err)
+	value, err := suite.registry.GetString("test_register_e.value")
+	suite.NoError(err)
+	suite.Equal("ok", value)
+
+	// Test failing loader returns its error and leaves the section empty
+	readErr := errors.New("file not found")
+	err = suite.registry.RegisterE("test_register_e_error", func(registry configContracts.ConfigRegistry) (map[string]interface{}, error) {
+		return nil, readErr
+	})
+	suite.ErrorIs(err, readErr)
+	suite.Contains(err.Error(), "loader for section 'test_register_e_error' failed")
+	section, err := suite.registry.Get("test_register_e_error")
+	suite.NoError(err)
+	suite.Empty(section)
+
+	// Test panicking loader returns the recovered value
+	err = suite.registry.RegisterE("test_register_e_panic", func(registry configContracts.ConfigRegistry) (map[string]interface{}, error) {
+		panic("broken loader")
+	})
+	suite.Error(err)
+	suite.Contains(err.Error(), "loader for section 'test_register_e_panic' panicked: broken loader")
+
+	// Test Refresh collects loader errors and keeps the previous configuration
+	fail := false
+	err = suite.registry.RegisterE("test_register_e_refresh", func(registry configContracts.ConfigRegistry) (map[string]interface{}, error) {
+		if fail {
+			return nil, readErr
+		}
+		return map[string]interface{}{"value": "loaded"}, nil
+	})
+	suite.NoError(err)
+	fail = true
+	err = suite.registry.Refresh()
+	suite.ErrorIs(err, readErr)
+	suite.Contains(err.Error(), "loader for section 'test_register_e_refresh' failed")
+	suite.Contains(err.Error(), "loader for section 'test_register_e_panic' panicked")
+	value, err = suite.registry.GetString("test_register_e_refresh.value")
+	suite.NoError(err)
+	suite.Equal("loaded", value)
+
+	err = suite.registry.RefreshSection("test_register_e_refresh")
+	suite.ErrorIs(err, readErr)
+
+	// Test read-only registries reject registration
+	err = suite.registry.ReadOnly().RegisterE("test_register_e", nil)
+	suite.ErrorIs(err, gonfig.ErrReadOnly)
+}
+
+// TestRefreshSection tests reloading a single section
+func (suite *ConfigTestSuite) TestRefreshSection() {
+	calls := map[string]int{}
+	suite.registry.Register("refresh_a", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		calls["a"]++
+		return map[string]interface{}{"calls": calls["a"]}
+	})
+	suite.registry.Register("refresh_b", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		calls["b"]++
+		return map[string]interface{}{"calls": calls["b"]}
+	})
+
+	// Test only the requested section is reloaded
+	err := suite.registry.RefreshSection("refresh_a")
+	suite.NoError(err)
+	suite.Equal(2, calls["a"])
+	suite.Equal(1, calls["b"])
+
+	value, err := suite.registry.GetInt("refresh_a.calls")
+	suite.NoError(err)
+	suite.Equal(2, value)
+
+	// Test unregistered section
+	err = suite.registry.RefreshSection("refresh_missing")
+	suite.ErrorIs(err, gonfig.ErrSectionNotFound)
+	suite.Contains(err.Error(), "config section not registered: 'refresh_missing'")
+
+	// Test panicking loader keeps the previous values
+	panicking := false
+	suite.registry.Register("refresh_panic", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		if panicking {
+			panic("reload failed")
+		}
+		return map[string]interface{}{"value": "kept"}
+	})
+	panicking = true
+	err = suite.registry.RefreshSection("refresh_panic")
+	suite.Error(err)
+	suite.Contains(err.Error(), "loader for section 'refresh_panic' panicked: reload failed")
+
+	str, err := suite.registry.GetString("refresh_panic.value")
+	suite.NoError(err)
+	suite.Equal("kept", str)
+}
+
+// TestPolling tests periodic background refreshes
+func (suite *ConfigTestSuite) TestPolling() {
+	var calls atomic.Int32
+	suite.registry.Register("polling", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{"calls": int(calls.Add(1))}
+	})
+
+	// Test double start only runs one poller
+	suite.registry.StartPolling(5 * time.Millisecond)
+	suite.registry.StartPolling(5 * time.Millisecond)
+	suite.Eventually(func() bool {
+		return calls.Load() >= 3
+	}, time.Second, time.Millisecond)
+
+	// Test stop halts refreshes
+	suite.registry.StopPolling()
+	stopped := calls.Load()
+	time.Sleep(20 * time.Millisecond)
+	suite.Equal(stopped, calls.Load())
+
+	// Test stopping again is a no-op
+	suite.NotPanics(func() {
+		suite.registry.StopPolling()
+	})
+}
+
+// TestGenericGet tests the generic typed accessor
+func (suite *ConfigTestSuite) TestGenericGet() {
+	str, err := gonfig.Get[string](suite.registry, "test.string_value")
+	suite.NoError(err)
+	suite.Equal("test", str)
+
+	i, err := gonfig.Get[int](suite.registry, "test.int_value")
+	suite.NoError(err)
+	suite.Equal(42, i)
+
+	i64, err := gonfig.Get[int64](suite.registry, "test.int_value")
+	suite.NoError(err)
+	suite.Equal(int64(42), i64)
+
+	b, err := gonfig.Get[bool](suite.registry, "test.bool_value")
+	suite.NoError(err)
+	suite.True(b)
+
+	f, err := gonfig.Get[float64](suite.registry, "test.float_value")
+	suite.NoError(err)
+	suite.Equal(3.14, f)
+
+	arr, err := gonfig.Get[[]string](suite.registry, "test.string_for_array")
+	suite.NoError(err)
+	suite.Equal([]string{"one", "two", "three"}, arr)
+
+	// Test default values
+	i64, err = gonfig.Get[int64](suite.registry, "test.nonexistent", 7)
+	suite.NoError(err)
+	suite.Equal(int64(7), i64)
+
+	// Test int64 values are not narrowed to int
+	suite.registry.Set("test.large_value", "9223372036854775807")
+	i64, err = gonfig.Get[int64](suite.registry, "test.large_value")
+	suite.NoError(err)
+	suite.Equal(int64(math.MaxInt64), i64)
+	i64, err = gonfig.Get[int64](suite.registry, "test.nonexistent", math.MaxInt64)
+	suite.NoError(err)
+	suite.Equal(int64(math.MaxInt64), i64)
+	suite.registry.Set("test.large_value", 1.5)
+	_, err = gonfig.Get[int64](suite.registry, "test.large_value")
+	suite.ErrorIs(err, gonfig.ErrTypeConversion)
+
+	// Test conversion errors return the zero value
+	i, err = gonfig.Get[int](suite.registry, "test.string_value")
+	suite.ErrorIs(err, gonfig.ErrTypeConversion)
+	suite.Equal(0, i)
+
+	// Test unsupported types
+	_, err = gonfig.Get[complex128](suite.registry, "test.float_value")
+	suite.ErrorIs(err, gonfig.ErrTypeConversion)
+	suite.Contains(err.Error(), "unsupported type complex128 for path 'test.float_value'")
+}
+
+// TestMustGet tests the panicking accessor variants
+func (suite *ConfigTestSuite) TestMustGet() {
+	suite.Equal("test", suite.registry.MustGetString("test.string_value"))
+	suite.Equal(42, suite.registry.MustGetInt("test.int_value"))
+	suite.Equal(true, suite.registry.MustGetBool("test.bool_value"))
+	suite.Equal(3.14, suite.registry.MustGetFloat("test.float_value"))
+	suite.Equal([]string{"one", "two", "three"}, suite.registry.MustGetStringArray("test.array_value"))
+
+	// Test missing and invalid values panic with the accessor error
+	suite.PanicsWithError("key not found: 'nonexistent' in path 'test.nonexistent'", func() {
+		suite.registry.MustGetString("test.nonexistent")
+	})
+	suite.Panics(func() {
+		suite.registry.MustGetInt("test.string_value")
+	})
+	suite.Panics(func() {
+		suite.registry.MustGetBool("test.float_value")
+	})
+	suite.Panics(func() {
+		suite.registry.MustGetFloat("test.bool_value")
+	})
+	suite.Panics(func() {
+		suite.registry.MustGetStringArray("test.int_value")
+	})
+}
+
+// TestBind tests keeping a struct in sync across refreshes
+func (suite *ConfigTestSuite) TestBind() {
+	type ServerConfig struct {
+		Host string `config:"host"`
+		Port int    `config:"port"`
+	}
+
+	values := map[string]interface{}{"host": "localhost", "port": 8080}
+	suite.registry.Register("bind_server", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		copied := make(map[string]interface{}, len(values))
+		for key, value := range values {
+			copied[key] = value
+		}
+		return copied
+	})
+
+	var config ServerConfig
+	err := suite.registry.Bind("bind_server", &config)
+	suite.NoError(err)
+	suite.Equal(ServerConfig{Host: "localhost", Port: 8080}, config)
+
+	// Test Refresh re-populates the struct
+	values["port"] = 9090
+	suite.registry.Refresh()
+	suite.Equal(9090, config.Port)
+
+	// Test RefreshSection re-populates the struct and clears removed keys
+	delete(values, "host")
+	err = suite.registry.RefreshSection("bind_server")
+	suite.NoError(err)
+	suite.Equal(ServerConfig{Port: 9090}, config)
+
+	// Test invalid targets and sections
+	suite.Error(suite.registry.Bind("bind_server", config))
+	suite.ErrorIs(suite.registry.Bind("bind_missing", &config), gonfig.ErrSectionNotFound)
+}
+
+// TestUnmarshalTimeTypes tests unmarshaling time.Duration and time.Time fields
+func (suite *ConfigTestSuite) TestUnmarshalTimeTypes() {
+	type TimingConfig struct {
+		Timeout   time.Duration `config:"timeout"`
+		Interval  time.Duration `config:"interval"`
+		StartedAt time.Time     `config:"started_at"`
+		Expires   time.Time     `config:"expires" timeformat:"2006-01-02"`
+	}
+
+	suite.registry.Register("timing", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{
+			"timeout":    "1m30s",
+			"interval":   int64(5 * time.Second),
+			"started_at": "2024-03-01T10:00:00Z",
+			"expires":    "2025-12-31",
+		}
+	})
+
+	var config TimingConfig
+	err := suite.registry.Unmarshal("timing", &config)
+	suite.NoError(err)
+	suite.Equal(90*time.Second, config.Timeout)
+	suite.Equal(5*time.Second, config.Interval)
+	suite.Equal(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), config.StartedAt)
+	suite.Equal(time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), config.Expires)
+
+	// Test invalid values
+	suite.registry.Register("timing_invalid", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{
+			"timeout":    "soon",
+			"started_at": "yesterday",
+		}
+	})
+	err = suite.registry.Unmarshal("timing_invalid", &config)
+	suite.Error(err)
+	suite.Contains(err.Error(), "error setting field 'timeout'")
+
+	var timeOnly struct {
+		StartedAt time.Time `config:"started_at"`
+	}
+	err = suite.registry.Unmarshal("timing_invalid", &timeOnly)
+	suite.Error(err)
+	suite.Contains(err.Error(), "error setting field 'started_at'")
+}
+
+// Backend is a discriminated union decoded through FromConfig
+type Backend struct {
+	Kind string
+	Addr string
+}
+
+// FromConfig decodes either a plain address string or a map with a kind and address
+func (b *Backend) FromConfig(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		b.Kind, b.Addr = "tcp", v
+	case map[string]interface{}:
+		kind, _ := v["kind"].(string)
+		if kind != "tcp" && kind != "unix" {
+			return fmt.Errorf("unknown backend kind '%v'", v["kind"])
+		}
+		b.Kind = kind
+		b.Addr, _ = v["addr"].(string)
+	default:
+		return fmt.Errorf("unsupported backend value %T", value)
+	}
+	return nil
+}
+
+// TestUnmarshalConfigDecoder tests fields that decode themselves via FromConfig
+func (suite *ConfigTestSuite) TestUnmarshalConfigDecoder() {
+	type ProxyConfig struct {
+		Primary  Backend  `config:"primary"`
+		Fallback *Backend `config:"fallback"`
+	}
+
+	suite.registry.Register("proxy", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{
+			"primary": "localhost:8080",
+			"fallback": map[string]interface{}{
+				"kind": "unix",
+				"addr": "/var/run/proxy.sock",
+			},
+		}
+	})
+
+	var config ProxyConfig
+	err := suite.registry.Unmarshal("proxy", &config)
+	suite.NoError(err)
+	suite.Equal(Backend{Kind: "tcp", Addr: "localhost:8080"}, config.Primary)
+	suite.Equal(&Backend{Kind: "unix", Addr: "/var/run/proxy.sock"}, config.Fallback)
+
+	// Test decoder errors are reported for the field
+	suite.registry.Register("proxy_invalid", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{
+			"primary": map[string]interface{}{"kind": "udp"},
+		}
+	})
+	err = suite.registry.Unmarshal("proxy_invalid", &config)
+	suite.Error(err)
+	suite.Contains(err.Error(), "error setting field 'primary'")
+	suite.Contains(err.Error(), "unknown backend kind 'udp'")
+}
+
+// TestUnmarshalDottedKeys tests struct tags that reach into nested maps
+func (suite *ConfigTestSuite) TestUnmarshalDottedKeys() {
+	type PoolConfig struct {
+		Driver         string `config:"driver"`
+		MaxConnections int    `config:"options.pool.max_connections"`
+		FirstReplica   string `config:"replicas.0.host"`
+		Legacy         string `config:"legacy.key"`
+		Missing        string `config:"options.pool.missing"`
+	}
+
+	suite.registry.Register("pool", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{
+			"driver": "postgres",
+			"options": map[string]interface{}{
+				"pool": map[string]interface{}{
+					"max_connections": 25,
+				},
+			},
+			"replicas": []interface{}{
+				map[string]interface{}{"host": "replica-1"},
+			},
+			"legacy.key": "literal",
+		}
+	})
+
+	var config PoolConfig
+	err := suite.registry.Unmarshal("pool", &config)
+	suite.NoError(err)
+	suite.Equal(PoolConfig{
+		Driver:         "postgres",
+		MaxConnections: 25,
+		FirstReplica:   "replica-1",
+		Legacy:         "literal",
+	}, config)
+
+	// Test required dotted keys
+	var required struct {
+		Missing string `config:"options.pool.missing" required:"true"`
+	}
+	err = suite.registry.Unmarshal("pool", &required)
+	suite.ErrorIs(err, gonfig.ErrKeyNotFound)
+
+	// Test paths through non-container values
+	var invalid struct {
+		Value string `config:"driver.name"`
+	}
+	err = suite.registry.Unmarshal("pool", &invalid)
+	suite.ErrorIs(err, gonfig.ErrInvalidPath)
+}
+
+// TestUnmarshalValidateTags tests validate struct tags during Unmarshal
+func (suite *ConfigTestSuite) TestUnmarshalValidateTags() {
+	type ListenerConfig struct {
+		Port     int           `config:"port" validate:"min=1,max=65535"`
+		LogLevel string        `config:"log_level" validate:"oneof=debug info warn error"`
+		Name     string        `config:"name" validate:"min=3"`
+		Timeout  time.Duration `config:"timeout" validate:"max=1m"`
+	}
+
+	values := map[string]interface{}{
+		"port":      8080,
+		"log_level": "info",
+		"name":      "api",
+		"timeout":   "30s",
+	}
+	suite.registry.Register("listener", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return values
+	})
+
+	var config ListenerConfig
+	err := suite.registry.Unmarshal("listener", &config)
+	suite.NoError(err)
+	suite.Equal(ListenerConfig{Port: 8080, LogLevel: "info", Name: "api", Timeout: 30 * time.Second}, config)
+
+	tests := []struct {
+		key   string
+		value interface{}
+		rule  string
+	}{
+		{"port", 0, "min=1"},
+		{"port", 70000, "max=65535"},
+		{"log_level", "verbose", "oneof=debug info warn error"},
+		{"name", "db", "min=3"},
+		{"timeout", "2m", "max=1m"},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.key+"/"+tt.rule, func() {
+			original := values[tt.key]
+			values[tt.key] = tt.value
+			defer func() { values[tt.key] = original }()
+
+			err := suite.registry.RefreshSection("listener")
+			suite.NoError(err)
+			err = suite.registry.Unmarshal("listener", &config)
+			suite.Error(err)
+			suite.Contains(err.Error(), fmt.Sprintf("error setting field '%s'", tt.key))
+			suite.Contains(err.Error(), fmt.Sprintf("validation rule '%s' failed", tt.rule))
+		})
+	}
+
+	// Test unknown rules
+	var unknown struct {
+		Port int `config:"port" validate:"positive"`
+	}
+	suite.NoError(suite.registry.RefreshSection("listener"))
+	err = suite.registry.Unmarshal("listener", &unknown)
+	suite.Error(err)
+	suite.Contains(err.Error(), "validation rule 'positive' failed: unknown rule")
+}
+
+// TestStringArrayOptions tests custom separators and omitting empty segments
+func (suite *ConfigTestSuite) TestStringArrayOptions() {
+	suite.registry.Register("lists", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{
+			"hosts": "a, ,b,",
+			"piped": "a | b|c",
+		}
+	})
+	defer suite.registry.SetArraySeparator("")
+	defer suite.registry.SetArrayOmitEmpty(false)
+
+	// Test default behavior keeps empty segments
+	value, err := suite.registry.GetStringArray("lists.hosts")
+	suite.NoError(err)
+	suite.Equal([]string{"a", "", "b", ""}, value)
+
+	// Test omitting empty segments
+	suite.registry.SetArrayOmitEmpty(true)
+	value, err = suite.registry.GetStringArray("lists.hosts")
+	suite.NoError(err)
+	suite.Equal([]string{"a", "b"}, value)
+
+	// Test custom separator
+	suite.registry.SetArraySeparator("|")
+	value, err = suite.registry.GetStringArray("lists.piped")
+	suite.NoError(err)
+	suite.Equal([]string{"a", "b", "c"}, value)
+
+	// Test resetting the separator
+	suite.registry.SetArraySeparator("")
+	value, err = suite.registry.GetStringArray("lists.hosts")
+	suite.NoError(err)
+	suite.Equal([]string{"a", "b"}, value)
+}
+
+// TestNumericArrays tests retrieving integer and float arrays
+func (suite *ConfigTestSuite) TestNumericArrays() {
+	suite.registry.Register("numeric_arrays", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{
+			"ports":    []int{80, 443},
+			"mixed":    []interface{}{1, "2", 3.0},
+			"csv":      "8080, 8081,8082",
+			"weights":  []interface{}{0.5, 1, "1.5"},
+			"invalid":  []interface{}{1, "two", 3},
+			"scalar":   true,
+			"fraction": []interface{}{1.5, 2.0},
+			"overflow": []interface{}{1, "99999999999999999999"},
+		}
+	})
+
+	ints, err := suite.registry.GetIntArray("numeric_arrays.ports")
+	suite.NoError(err)
+	suite.Equal([]int{80, 443}, ints)
+
+	ints, err = suite.registry.GetIntArray("numeric_arrays.mixed")
+	suite.NoError(err)
+	suite.Equal([]int{1, 2, 3}, ints)
+
+	ints, err = suite.registry.GetIntArray("numeric_arrays.csv")
+	suite.NoError(err)
+	suite.Equal([]int{8080, 8081, 8082}, ints)
+
+	floats, err := suite.registry.GetFloatArray("numeric_arrays.weights")
+	suite.NoError(err)
+	suite.Equal([]float64{0.5, 1, 1.5}, floats)
+
+	floats, err = suite.registry.GetFloatArray("numeric_arrays.csv")
+	suite.NoError(err)
+	suite.Equal([]float64{8080, 8081, 8082}, floats)
+
+	// Test defaults
+	ints, err = suite.registry.GetIntArray("numeric_arrays.nonexistent", []int{1})
+	suite.NoError(err)
+	suite.Equal([]int{1}, ints)
+
+	floats, err = suite.registry.GetFloatArray("numeric_arrays.nonexistent", []float64{1.5})
+	suite.NoError(err)
+	suite.Equal([]float64{1.5}, floats)
+
+	// Test conversion errors report the failing index
+	_, err = suite.registry.GetIntArray("numeric_arrays.invalid")
+	suite.ErrorIs(err, gonfig.ErrTypeConversion)
+	suite.Contains(err.Error(), "index 1")
+
+	_, err = suite.registry.GetFloatArray("numeric_arrays.invalid")
+	suite.ErrorIs(err, gonfig.ErrTypeConversion)
+	suite.Contains(err.Error(), "index 1")
+
+	_, err = suite.registry.GetIntArray("numeric_arrays.scalar")
+	suite.ErrorIs(err, gonfig.ErrTypeConversion)
+
+	// Test elements are checked like GetInt checks them
+	_, err = suite.registry.GetIntArray("numeric_arrays.fraction")
+	suite.ErrorIs(err, gonfig.ErrTypeConversion)
+	suite.Contains(err.Error(), "index 0")
+	suite.Contains(err.Error(), "is not an integer")
+
+	_, err = suite.registry.GetIntArray("numeric_arrays.overflow")
+	suite.ErrorIs(err, gonfig.ErrTypeConversion)
+	suite.Contains(err.Error(), "index 1")
+	suite.Contains(err.Error(), "overflows int")
+}
+
+// TestGetBytes tests parsing human-readable sizes
+func (suite *ConfigTestSuite) TestGetBytes() {
+	suite.registry.Register("limits", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{
+			"raw":       1024,
+			"float":     float64(4096),
+			"unsigned":  uint32(256),
+			"plain":     "2048",
+			"bytes":     "100B",
+			"kilobytes": "512KB",
+			"megabytes": "10 MB",
+			"kibibytes": "4KiB",
+			"gibibytes": "2GiB",
+			"fraction":  "1.5mb",
+			"unknown":   "10XB",
+			"invalid":   "large",
+			"overflow":  "100000000TB",
+			"boolean":   true,
+			"partial":   1.5,
+			"negative":  -1,
+			"huge":      1e19,
+		}
+	})
+
+	tests := []struct {
+		key      string
+		expected int64
+	}{
+		{"raw", 1024},
+		{"float", 4096},
+		{"unsigned", 256},
+		{"plain", 2048},
+		{"bytes", 100},
+		{"kilobytes", 512000},
+		{"megabytes", 10000000},
+		{"kibibytes", 4096},
+		{"gibibytes", 2 << 30},
+		{"fraction", 1500000},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.key, func() {
+			value, err := suite.registry.GetBytes("limits." + tt.key)
+			suite.NoError(err)
+			suite.Equal(tt.expected, value)
+		})
+	}
+
+	// Test default value
+	value, err := suite.registry.GetBytes("limits.nonexistent", 64)
+	suite.NoError(err)
+	suite.Equal(int64(64), value)
+
+	// Test invalid sizes
+	for _, key := range []string{"unknown", "invalid", "overflow", "boolean", "partial", "negative", "huge"} {
+		_, err := suite.registry.GetBytes("limits." + key)
+		suite.ErrorIs(err, gonfig.ErrTypeConversion, key)
+	}
+}
+
+// TestConcurrentAccess hammers Get, Set and Refresh from many goroutines.
+// Run with -race to detect unsynchronized access to shared configuration maps.
+func (suite *ConfigTestSuite) TestConcurrentAccess() {
+	suite.registry.Register("stress", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{
+			"counter": 0,
+			"nested": map[string]interface{}{
+				"value": "initial",
+				"items": []interface{}{"a", "b", "c"},
+			},
+		}
+	})
+
+	suite.registry.EnableValueCache(true)
+	defer suite.registry.EnableValueCache(false)
+
+	const workers = 8
+	const iterations = 200
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(3)
+
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				_, _ = suite.registry.GetString("stress.nested.value")
+				_, _ = suite.registry.GetInt("stress.counter")
+				_, _ = suite.registry.GetStringArray("stress.nested.items")
+				if nested, err := suite.registry.Get("stress.nested"); err == nil {
+					for range nested.(map[string]interface{}) {
+					}
+				}
+				if section, err := suite.registry.Get("stress"); err == nil {
+					_ = fmt.Sprint(section)
+				}
+			}
+		}()
+
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				_ = suite.registry.Set("stress.counter", i)
+				_ = suite.registry.Set("stress.nested.value", fmt.Sprintf("worker-%d", w))
+				_ = suite.registry.Set(fmt.Sprintf("stress.created.worker_%d.iteration", w), i)
+				_ = suite.registry.Set("stress.nested.items.1", "updated")
+			}
+		}(w)
+
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations/10; i++ {
+				_ = suite.registry.RefreshSection("stress")
+			}
+		}()
+	}
+	wg.Wait()
+
+	suite.registry.Refresh()
+	value, err := suite.registry.GetString("stress.nested.value")
+	suite.NoError(err)
+	suite.Equal("initial", value)
+}
+
+// TestGetReturnsCopies tests that mutating values returned by Get leaves the registry unchanged
+func (suite *ConfigTestSuite) TestGetReturnsCopies() {
+	suite.registry.Register("copies", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{
+			"nested": map[string]interface{}{
+				"host": "localhost",
+				"deep": map[string]interface{}{"port": 8080},
+			},
+			"items": []interface{}{"a", map[string]interface{}{"b": "c"}},
+			"tags":  []string{"one", "two"},
+		}
+	})
+
+	// Test mutating a returned nested map
+	value, err := suite.registry.Get("copies.nested")
+	suite.NoError(err)
+	nested := value.(map[string]interface{})
+	nested["host"] = "mutated"
+	nested["deep"].(map[string]interface{})["port"] = 1
+	delete(nested, "deep")
+
+	host, err := suite.registry.GetString("copies.nested.host")
+	suite.NoError(err)
+	suite.Equal("localhost", host)
+	port, err := suite.registry.GetInt("copies.nested.deep.port")
+	suite.NoError(err)
+	suite.Equal(8080, port)
+
+	// Test mutating returned slices
+	value, err = suite.registry.Get("copies.items")
+	suite.NoError(err)
+	items := value.([]interface{})
+	items[0] = "mutated"
+	items[1].(map[string]interface{})["b"] = "mutated"
+
+	first, err := suite.registry.GetString("copies.items.0")
+	suite.NoError(err)
+	suite.Equal("a", first)
+	inner, err := suite.registry.GetString("copies.items.1.b")
+	suite.NoError(err)
+	suite.Equal("c", inner)
+
+	tags, err := suite.registry.GetStringArray("copies.tags")
+	suite.NoError(err)
+	tags[0] = "mutated"
+	tags, err = suite.registry.GetStringArray("copies.tags")
+	suite.NoError(err)
+	suite.Equal([]string{"one", "two"}, tags)
+
+	// Test mutating an unmarshaled slice field
+	var config struct {
+		Tags []string `config:"tags"`
+	}
+	suite.NoError(suite.registry.Unmarshal("copies", &config))
+	config.Tags[0] = "mutated"
+	tags, err = suite.registry.GetStringArray("copies.tags")
+	suite.NoError(err)
+	suite.Equal([]string{"one", "two"}, tags)
+}
+
+// TestSnapshotRestore tests capturing and restoring the configuration
+func (suite *ConfigTestSuite) TestSnapshotRestore() {
+	suite.registry.Register("snapshot", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{
+			"name": "original",
+			"nested": map[string]interface{}{
+				"port": 8080,
+			},
+		}
+	})
+
+	type SnapshotConfig struct {
+		Name string `config:"name"`
+	}
+	var bound SnapshotConfig
+	suite.NoError(suite.registry.Bind("snapshot", &bound))
+
+	snap := suite.registry.Snapshot()
+	suite.Contains(snap.Sections(), "snapshot")
+
+	// Test the snapshot is independent of later mutations
+	suite.NoError(suite.registry.Set("snapshot.name", "changed"))
+	suite.NoError(suite.registry.Set("snapshot.nested.port", 9090))
+	suite.registry.MergeFrom(map[string]map[string]interface{}{
+		"snapshot_extra": {"key": "value"},
+	})
+
+	// Test restoring the snapshot
+	suite.NoError(suite.registry.Restore(snap))
+
+	name, err := suite.registry.GetString("snapshot.name")
+	suite.NoError(err)
+	suite.Equal("original", name)
+	port, err := suite.registry.GetInt("snapshot.nested.port")
+	suite.NoError(err)
+	suite.Equal(8080, port)
+	suite.Equal("original", bound.Name)
+
+	_, err = suite.registry.Get("snapshot_extra")
+	suite.ErrorIs(err, gonfig.ErrSectionNotFound)
+
+	// Test the snapshot can be restored again after further changes
+	suite.NoError(suite.registry.Set("snapshot.name", "changed again"))
+	suite.NoError(suite.registry.Restore(snap))
+	name, err = suite.registry.GetString("snapshot.name")
+	suite.NoError(err)
+	suite.Equal("original", name)
+
+	// Test invalid snapshots and read-only views
+	suite.Error(suite.registry.Restore(nil))
+	suite.ErrorIs(suite.registry.ReadOnly().Restore(snap), gonfig.ErrReadOnly)
+}
+
+// TestTransaction tests all-or-nothing updates
+func (suite *ConfigTestSuite) TestTransaction() {
+	suite.registry.Register("tx", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{
+			"host": "localhost",
+			"port": 8080,
+			"tls":  false,
+		}
+	})
+
+	// Test committed writes and read-through of untouched keys
+	err := suite.registry.Transaction(func(tx configContracts.ConfigRegistry) error {
+		if err := tx.Set("tx.port", 8443); err != nil {
+			return err
+		}
+		if err := tx.SetBool("tx.tls", true); err != nil {
+			return err
+		}
+		if err := tx.Unset("tx.host"); err != nil {
+			return err
+		}
+
+		port, err := tx.GetInt("tx.port")
+		suite.NoError(err)
+		suite.Equal(8443, port)
+
+		// Changes are not visible outside the transaction before commit
+		port, err = suite.registry.GetInt("tx.port")
+		suite.NoError(err)
+		suite.Equal(8080, port)
+		return nil
+	})
+	suite.NoError(err)
+
+	port, err := suite.registry.GetInt("tx.port")
+	suite.NoError(err)
+	suite.Equal(8443, port)
+	tls, err := suite.registry.GetBool("tx.tls")
+	suite.NoError(err)
+	suite.True(tls)
+	_, err = suite.registry.Get("tx.host")
+	suite.ErrorIs(err, gonfig.ErrKeyNotFound)
+
+	// Test rollback when the callback fails
+	failure := errors.New("validation failed")
+	err = suite.registry.Transaction(func(tx configContracts.ConfigRegistry) error {
+		suite.NoError(tx.Set("tx.port", 1))
+		return failure
+	})
+	suite.ErrorIs(err, failure)
+	port, err = suite.registry.GetInt("tx.port")
+	suite.NoError(err)
+	suite.Equal(8443, port)
+
+	// Test rollback when a write fails on commit
+	snap := suite.registry.Snapshot()
+	suite.registry.MergeFrom(map[string]map[string]interface{}{"tx_temp": {"key": "value"}})
+	err = suite.registry.Transaction(func(tx configContracts.ConfigRegistry) error {
+		suite.NoError(tx.Set("tx.port", 1))
+		suite.NoError(tx.Set("tx_temp.key", "staged"))
+		return suite.registry.Restore(snap)
+	})
+	suite.ErrorIs(err, gonfig.ErrSectionNotFound)
+	port, err = suite.registry.GetInt("tx.port")
+	suite.NoError(err)
+	suite.Equal(8443, port)
+
+	// Test failing writes are reported and other mutations are rejected
+	err = suite.registry.Transaction(func(tx configContracts.ConfigRegistry) error {
+		suite.ErrorIs(tx.Set("tx_missing.key", 1), gonfig.ErrSectionNotFound)
+		suite.ErrorIs(tx.RefreshSection("tx"), gonfig.ErrReadOnly)
+		return nil
+	})
+	suite.NoError(err)
+}
+
+// TestGetEnvFloatAndDuration tests float and duration environment accessors
+func (suite *ConfigTestSuite) TestGetEnvFloatAndDuration() {
+	suite.T().Setenv("GONFIG_TEST_RATIO", "0.75")
+	suite.T().Setenv("GONFIG_TEST_TIMEOUT", "1m30s")
+	suite.T().Setenv("GONFIG_TEST_INVALID", "soon")
+
+	suite.Equal(0.75, suite.registry.GetEnvFloat("GONFIG_TEST_RATIO", 1))
+	suite.Equal(1.5, suite.registry.GetEnvFloat("GONFIG_TEST_MISSING", 1.5))
+	suite.Equal(1.5, suite.registry.GetEnvFloat("GONFIG_TEST_INVALID", 1.5))
+
+	suite.Equal(90*time.Second, suite.registry.GetEnvDuration("GONFIG_TEST_TIMEOUT", time.Second))
+	suite.Equal(time.Second, suite.registry.GetEnvDuration("GONFIG_TEST_MISSING", time.Second))
+	suite.Equal(time.Second, suite.registry.GetEnvDuration("GONFIG_TEST_INVALID", time.Second))
+}
+
+// TestGetEnvBool tests the truthy and falsy values accepted by GetEnvBool
+func (suite *ConfigTestSuite) TestGetEnvBool() {
+	tests := []struct {
+		value        string
+		defaultValue bool
+		expected     bool
+	}{
+		{"true", false, true},
+		{"TRUE", false, true},
+		{"1", false, true},
+		{"yes", false, true},
+		{"On", false, true},
+		{"false", true, false},
+		{"0", true, false},
+		{"no", true, false},
+		{"OFF", true, false},
+		{"maybe", true, true},
+		{"maybe", false, false},
+		{"", true, true},
+	}
+
+	for _, tt := range tests {
+		suite.Run(fmt.Sprintf("%q/default=%v", tt.value, tt.defaultValue), func() {
+			suite.T().Setenv("GONFIG_TEST_BOOL", tt.value)
+			suite.Equal(tt.expected, suite.registry.GetEnvBool("GONFIG_TEST_BOOL", tt.defaultValue))
+		})
+	}
+
+	suite.True(suite.registry.GetEnvBool("GONFIG_TEST_BOOL_MISSING", true))
+}
+
+// TestRegisterEnvPrefix tests building a section from prefixed environment variables
+func (suite *ConfigTestSuite) TestRegisterEnvPrefix() {
+	suite.T().Setenv("GONFIGENV_NAME", "service")
+	suite.T().Setenv("GONFIGENV_DB__HOST", "localhost")
+	suite.T().Setenv("GONFIGENV_DB__POOL__MAX_SIZE", "10")
+	suite.T().Setenv("OTHER_DB__HOST", "ignored")
+
+	suite.registry.RegisterEnvPrefix("envprefix", "GONFIGENV_")
+
+	name, err := suite.registry.GetString("envprefix.name")
+	suite.NoError(err)
+	suite.Equal("service", name)
+
+	host, err := suite.registry.GetString("envprefix.db.host")
+	suite.NoError(err)
+	suite.Equal("localhost", host)
+
+	size, err := suite.registry.GetInt("envprefix.db.pool.max_size")
+	suite.NoError(err)
+	suite.Equal(10, size)
+
+	_, err = suite.registry.Get("envprefix.other")
+	suite.ErrorIs(err, gonfig.ErrKeyNotFound)
+
+	// Test refreshing re-scans the environment
+	suite.T().Setenv("GONFIGENV_DB__HOST", "db.internal")
+	suite.NoError(suite.registry.RefreshSection("envprefix"))
+	host, err = suite.registry.GetString("envprefix.db.host")
+	suite.NoError(err)
+	suite.Equal("db.internal", host)
+}
+
+// TestLogger tests that registry operations are reported to the logger
+func (suite *ConfigTestSuite) TestLogger() {
+	var buf bytes.Buffer
+	suite.registry.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer suite.registry.SetLogger(nil)
+
+	suite.registry.Register("logged", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{"port": 8080}
+	})
+	suite.Contains(buf.String(), `level=DEBUG msg="config section registered" section=logged`)
+
+	// Test defaults used for missing paths are reported as warnings
+	buf.Reset()
+	port, err := suite.registry.GetInt("logged.prot", 9090)
+	suite.NoError(err)
+	suite.Equal(9090, port)
+	suite.Contains(buf.String(), `level=DEBUG msg="config path not found" path=logged.prot`)
+	suite.Contains(buf.String(), `level=WARN msg="config path missing, using default" path=logged.prot`)
+
+	// Test refreshes report each section and the total
+	buf.Reset()
+	suite.NoError(suite.registry.RefreshSection("logged"))
+	suite.Contains(buf.String(), `msg="config section reloaded" section=logged`)
+
+	buf.Reset()
+	_ = suite.registry.Refresh()
+	suite.Contains(buf.String(), `msg="config section reloaded" section=logged`)
+	suite.Contains(buf.String(), `level=INFO msg="config refreshed"`)
+	suite.Contains(buf.String(), "duration=")
+
+	// Test nothing is logged once the logger is removed
+	suite.registry.SetLogger(nil)
+	buf.Reset()
+	_, _ = suite.registry.GetInt("logged.prot", 9090)
+	suite.Empty(buf.String())
+}
+
+// recordingObserver records the metrics reported by the registry
+type recordingObserver struct {
+	hits      map[string]int
+	misses    map[string]int
+	refreshes []time.Duration
+}
+
+func (o *recordingObserver) ObserveGet(path string, hit bool) {
+	if hit {
+		o.hits[path]++
+	} else {
+		o.misses[path]++
+	}
+}
+
+func (o *recordingObserver) ObserveRefresh(duration time.Duration) {
+	o.refreshes = append(o.refreshes, duration)
+}
+
+// TestMetricsObserver tests that lookups and refreshes are reported to the observer
+func (suite *ConfigTestSuite) TestMetricsObserver() {
+	obs := &recordingObserver{hits: map[string]int{}, misses: map[string]int{}}
+	suite.registry.SetMetricsObserver(obs)
+	defer suite.registry.SetMetricsObserver(nil)
+
+	suite.registry.Register("observed", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{"port": 8080}
+	})
+
+	_, _ = suite.registry.Get("observed.port")
+	_, _ = suite.registry.GetInt("observed.port")
+	_, _ = suite.registry.GetInt("observed.missing", 1)
+	suite.Equal(2, obs.hits["observed.port"])
+	suite.Equal(1, obs.misses["observed.missing"])
+
+	_ = suite.registry.Refresh()
+	suite.Len(obs.refreshes, 1)
+
+	// Test the observer is no longer called once removed
+	suite.registry.SetMetricsObserver(nil)
+	_, _ = suite.registry.Get("observed.port")
+	suite.Equal(2, obs.hits["observed.port"])
+}
+
+// TestValueCache tests that cached accessor results are invalidated by every write
+func (suite *ConfigTestSuite) TestValueCache() {
+	values := map[string]interface{}{"port": 8080, "ratio": 0.5, "name": "api", "debug": "true"}
+	suite.registry.Register("cached", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		copied := make(map[string]interface{}, len(values))
+		for key, value := range values {
+			copied[key] = value
+		}
+		return copied
+	})
+	suite.registry.EnableValueCache(true)
+	defer suite.registry.EnableValueCache(false)
+
+	assertPort := func(expected int) {
+		suite.T().Helper()
+		for i := 0; i < 2; i++ {
+			port, err := suite.registry.GetInt("cached.port")
+			suite.NoError(err)
+			suite.Equal(expected, port)
+		}
+	}
+
+	assertPort(8080)
+	name, err := suite.registry.GetString("cached.name")
+	suite.NoError(err)
+	suite.Equal("api", name)
+	debug, err := suite.registry.GetBool("cached.debug")
+	suite.NoError(err)
+	suite.True(debug)
+	ratio, err := suite.registry.GetFloat("cached.ratio")
+	suite.NoError(err)
+	suite.Equal(0.5, ratio)
+
+	// Test invalidation by each kind of write
+	suite.NoError(suite.registry.Set("cached.port", 9090))
+	assertPort(9090)
+
+	values["port"] = 7070
+	suite.NoError(suite.registry.RefreshSection("cached"))
+	assertPort(7070)
+
+	values["port"] = 6060
+	_ = suite.registry.Refresh()
+	assertPort(6060)
+
+	suite.registry.MergeFrom(map[string]map[string]interface{}{"cached": {"port": 5050}})
+	assertPort(5050)
+
+	snap := suite.registry.Snapshot()
+	suite.NoError(suite.registry.Set("cached.port", 4040))
+	assertPort(4040)
+	suite.NoError(suite.registry.Restore(snap))
+	assertPort(5050)
+
+	suite.NoError(suite.registry.Transaction(func(tx configContracts.ConfigRegistry) error {
+		return tx.Set("cached.port", 3030)
+	}))
+	assertPort(3030)
+
+	suite.NoError(suite.registry.Unset("cached.port"))
+	port, err := suite.registry.GetInt("cached.port", 1)
+	suite.NoError(err)
+	suite.Equal(1, port)
+
+	// Test flag bindings are never served stale
+	flags := pflag.NewFlagSet("cached", pflag.ContinueOnError)
+	flags.String("name", "api", "")
+	suite.registry.(*gonfig.ConfigRegistry).BindPFlag("cached.name", flags.Lookup("name"))
+	suite.NoError(flags.Parse([]string{"--name=web"}))
+	name, err = suite.registry.GetString("cached.name")
+	suite.NoError(err)
+	suite.Equal("web", name)
+	suite.NoError(flags.Set("name", "worker"))
+	name, err = suite.registry.GetString("cached.name")
+	suite.NoError(err)
+	suite.Equal("worker", name)
+}
+
+func (suite *ConfigTestSuite) TestGetMany() {
+	suite.registry.Register("batch", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{
+			"name": "api",
+			"port": 8080,
+			"tags": []string{"a", "b"},
+		}
+	})
+
+	// Test all paths resolve
+	values, err := suite.registry.GetMany([]string{"batch.name", "batch.port"})
+	suite.NoError(err)
+	suite.Equal(map[string]interface{}{"batch.name": "api", "batch.port": 8080}, values)
+
+	// Test missing paths are reported per path without failing the batch
+	values, err = suite.registry.GetMany([]string{"batch.name", "batch.missing", "nosection.key"})
+	suite.Error(err)
+	suite.True(errors.Is(err, gonfig.ErrKeyNotFound))
+	suite.True(errors.Is(err, gonfig.ErrSectionNotFound))
+	suite.Equal(map[string]interface{}{"batch.name": "api"}, values)
+
+	// Test returned values are copies
+	values, err = suite.registry.GetMany([]string{"batch.tags"})
+	suite.NoError(err)
+	values["batch.tags"].([]string)[0] = "changed"
+	tags, err := suite.registry.GetStringArray("batch.tags")
+	suite.NoError(err)
+	suite.Equal([]string{"a", "b"}, tags)
+}
+
+func (suite *ConfigTestSuite) TestSetMany() {
+	suite.registry.Register("flags", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{
+			"search": false,
+			"beta":   false,
+		}
+	})
+
+	// Test all values are applied
+	suite.NoError(suite.registry.SetMany(map[string]interface{}{
+		"flags.search":       true,
+		"flags.beta":         true,
+		"flags.limits":       map[string]interface{}{"rate": 10},
+		"flags.limits.burst": 20,
+	}))
+	search, err := suite.registry.GetBool("flags.search")
+	suite.NoError(err)
+	suite.True(search)
+	beta, err := suite.registry.GetBool("flags.beta")
+	suite.NoError(err)
+	suite.True(beta)
+	burst, err := suite.registry.GetInt("flags.limits.burst")
+	suite.NoError(err)
+	suite.Equal(20, burst)
+
+	// Test nothing is applied when one path fails
+	err = suite.registry.SetMany(map[string]interface{}{
+		"flags.search":  false,
+		"missing.value": 1,
+	})
+	suite.Error(err)
+	suite.True(errors.Is(err, gonfig.ErrSectionNotFound))
+	search, err = suite.registry.GetBool("flags.search")
+	suite.NoError(err)
+	suite.True(search)
+
+	// Test SetMany is staged inside a transaction
+	suite.NoError(suite.registry.Transaction(func(tx configContracts.ConfigRegistry) error {
+		if err := tx.SetMany(map[string]interface{}{"flags.beta": false}); err != nil {
+			return err
+		}
+		beta, err := tx.GetBool("flags.beta")
+		suite.NoError(err)
+		suite.False(beta)
+		return nil
+	}))
+	beta, err = suite.registry.GetBool("flags.beta")
+	suite.NoError(err)
+	suite.False(beta)
+
+	// Test read-only views reject SetMany
+	err = suite.registry.ReadOnly().SetMany(map[string]interface{}{"flags.beta": true})
+	suite.True(errors.Is(err, gonfig.ErrReadOnly))
+}
+
+func (suite *ConfigTestSuite) TestClone() {
+	calls := 0
+	suite.registry.Register("cloned", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		calls++
+		return map[string]interface{}{
+			"name":   "api",
+			"limits": map[string]interface{}{"rate": 10},
+		}
+	})
+
+	clone := suite.registry.Clone()
+	suite.NotSame(suite.registry, clone)
+
+	// Test writes to the clone don't reach the original, and vice versa
+	suite.NoError(clone.Set("cloned.limits.rate", 20))
+	suite.NoError(suite.registry.Set("cloned.name", "web"))
+
+	rate, err := suite.registry.GetInt("cloned.limits.rate")
+	suite.NoError(err)
+	suite.Equal(10, rate)
+	name, err := clone.GetString("cloned.name")
+	suite.NoError(err)
+	suite.Equal("api", name)
+
+	// Test the clone keeps its own loaders
+	suite.NoError(clone.RefreshSection("cloned"))
+	suite.Equal(2, calls)
+	rate, err = clone.GetInt("cloned.limits.rate")
+	suite.NoError(err)
+	suite.Equal(10, rate)
+	name, err = suite.registry.GetString("cloned.name")
+	suite.NoError(err)
+	suite.Equal("web", name)
+
+	// Test the singleton is unaffected
+	global, err := gonfig.GetConfigRegistry("testing")
+	suite.NoError(err)
+	suite.Same(suite.registry, global)
+}
+
+func (suite *ConfigTestSuite) TestNewConfigRegistry() {
+	first, err := gonfig.NewConfigRegistry("testing")
+	suite.NoError(err)
+	second, err := gonfig.NewConfigRegistry("testing")
+	suite.NoError(err)
+
+	// Test every call returns a fresh instance, separate from the singleton
+	suite.NotSame(first, second)
+	suite.NotSame(suite.registry, first)
+
+	first.Register("tenant", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{"name": "acme"}
+	})
+	second.Register("tenant", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{"name": "globex"}
+	})
+
+	name, err := first.GetString("tenant.name")
+	suite.NoError(err)
+	suite.Equal("acme", name)
+	name, err = second.GetString("tenant.name")
+	suite.NoError(err)
+	suite.Equal("globex", name)
+
+	_, err = suite.registry.Get("tenant.name")
+	suite.True(errors.Is(err, gonfig.ErrSectionNotFound))
+
+	// Test invalid environment names are rejected
+	_, err = gonfig.NewConfigRegistry("../prod")
+	suite.Error(err)
+}
+
+func (suite *ConfigTestSuite) TestRegistryOptions() {
+	// Test explicit env files are loaded, and missing ones are an error
+	file := filepath.Join(suite.T().TempDir(), "custom.env")
+	suite.NoError(os.WriteFile(file, []byte("GONFIG_OPTION_FILE=loaded\n"), 0o600))
+	defer os.Unsetenv("GONFIG_OPTION_FILE")
+
+	_, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles(file))
+	suite.NoError(err)
+	suite.Equal("loaded", os.Getenv("GONFIG_OPTION_FILE"))
+
+	_, err = gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles(file+".missing"))
+	suite.Error(err)
+
+	// Test the array separator and logger are applied
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	registry, err := gonfig.NewConfigRegistry("testing",
+		gonfig.WithEnvFiles(),
+		gonfig.WithArraySeparator("|"),
+		gonfig.WithLogger(logger),
+	)
+	suite.NoError(err)
+	registry.Register("options", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{"hosts": "a|b|c"}
+	})
+	hosts, err := registry.GetStringArray("options.hosts")
+	suite.NoError(err)
+	suite.Equal([]string{"a", "b", "c"}, hosts)
+	suite.Contains(buf.String(), "section=options")
+
+	// Test environment references are expanded on load and on refresh
+	suite.T().Setenv("GONFIG_OPTION_HOST", "db.internal")
+	values := map[string]interface{}{
+		"dsn":   "postgres://${GONFIG_OPTION_HOST}:5432",
+		"hosts": []interface{}{"$GONFIG_OPTION_HOST"},
+	}
+	registry, err = gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles(), gonfig.WithEnvInterpolation())
+	suite.NoError(err)
+	registry.Register("interpolated", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return values
+	})
+	dsn, err := registry.GetString("interpolated.dsn")
+	suite.NoError(err)
+	suite.Equal("postgres://db.internal:5432", dsn)
+	hosts, err = registry.GetStringArray("interpolated.hosts")
+	suite.NoError(err)
+	suite.Equal([]string{"db.internal"}, hosts)
+	suite.Equal("postgres://${GONFIG_OPTION_HOST}:5432", values["dsn"])
+
+	suite.T().Setenv("GONFIG_OPTION_HOST", "db.replica")
+	suite.NoError(registry.Refresh())
+	dsn, err = registry.GetString("interpolated.dsn")
+	suite.NoError(err)
+	suite.Equal("postgres://db.replica:5432", dsn)
+
+	// Test values are left alone without the option
+	registry, err = gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+	registry.Register("interpolated", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return values
+	})
+	dsn, err = registry.GetString("interpolated.dsn")
+	suite.NoError(err)
+	suite.Equal("postgres://${GONFIG_OPTION_HOST}:5432", dsn)
+}
+
+func (suite *ConfigTestSuite) TestUnmarshalEmbeddedStructs() {
+	type BaseConfig struct {
+		Name  string `config:"name"`
+		Debug bool   `config:"debug"`
+	}
+	type TLSConfig struct {
+		Cert string `config:"cert"`
+	}
+	type PoolConfig struct {
+		Size int `config:"size"`
+	}
+	type ServiceConfig struct {
+		BaseConfig
+		*TLSConfig
+		Pool    *PoolConfig `config:"pool"`
+		Timeout *int        `config:"timeout"`
+	}
+
+	suite.registry.Register("service", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{
+			"name":    "api",
+			"debug":   true,
+			"cert":    "/etc/tls/api.pem",
+			"pool":    map[string]interface{}{"size": 4},
+			"timeout": "30",
+		}
+	})
+
+	var config ServiceConfig
+	err := suite.registry.Unmarshal("service", &config)
+	suite.NoError(err)
+
+	// Test promoted fields bind at the top level
+	suite.Equal("api", config.Name)
+	suite.True(config.Debug)
+	suite.NotNil(config.TLSConfig)
+	suite.Equal("/etc/tls/api.pem", config.Cert)
+
+	// Test pointer fields are allocated
+	suite.NotNil(config.Pool)
+	suite.Equal(4, config.Pool.Size)
+	suite.NotNil(config.Timeout)
+	suite.Equal(30, *config.Timeout)
+}
+
+func (suite *ConfigTestSuite) TestMarshal() {
+	type BaseConfig struct {
+		Name string `config:"name"`
+	}
+	type PoolConfig struct {
+		Size int `config:"size"`
+	}
+	type ServerConfig struct {
+		BaseConfig
+		Port     int           `config:"port"`
+		Hosts    []string      `config:"hosts"`
+		Timeout  time.Duration `config:"timeout"`
+		Pool     PoolConfig    `config:"pool"`
+		MaxConns int           `config:"options.pool.max_connections"`
+		Replica  *PoolConfig   `config:"replica"`
+		Note     string        `config:"note" omitempty:"true"`
+		Secret   string        `config:"-"`
+	}
+
+	source := ServerConfig{
+		BaseConfig: BaseConfig{Name: "api"},
+		Port:       8080,
+		Hosts:      []string{"a", "b"},
+		Timeout:    90 * time.Second,
+		Pool:       PoolConfig{Size: 4},
+		MaxConns:   25,
+		Secret:     "hidden",
+	}
+	suite.NoError(suite.registry.Marshal("marshaled", &source))
+
+	// Test the section follows the Unmarshal tag rules
+	value, err := suite.registry.Get("marshaled")
+	suite.NoError(err)
+	suite.Equal(map[string]interface{}{
+		"name":    "api",
+		"port":    8080,
+		"hosts":   []string{"a", "b"},
+		"timeout": "1m30s",
+		"pool":    map[string]interface{}{"size": 4},
+		"options": map[string]interface{}{
+			"pool": map[string]interface{}{"max_connections": 25},
+		},
+	}, value)
+
+	// Test the section unmarshals back into the same struct
+	var decoded ServerConfig
+	suite.NoError(suite.registry.Unmarshal("marshaled", &decoded))
+	source.Secret = ""
+	suite.Equal(source, decoded)
+
+	// Test invalid sources are rejected
+	suite.Error(suite.registry.Marshal("marshaled", 42))
+	suite.Error(suite.registry.Marshal("marshaled", (*ServerConfig)(nil)))
+	suite.True(errors.Is(suite.registry.ReadOnly().Marshal("marshaled", source), gonfig.ErrReadOnly))
+}
+
+func (suite *ConfigTestSuite) TestRegisterMap() {
+	data := map[string]interface{}{
+		"name":   "api",
+		"limits": map[string]interface{}{"rate": 10},
+	}
+	suite.registry.RegisterMap("static", data)
+
+	name, err := suite.registry.GetString("static.name")
+	suite.NoError(err)
+	suite.Equal("api", name)
+
+	// Test later changes to the map are not picked up
+	data["name"] = "changed"
+	data["limits"].(map[string]interface{})["rate"] = 20
+	name, err = suite.registry.GetString("static.name")
+	suite.NoError(err)
+	suite.Equal("api", name)
+
+	// Test Refresh resets the section to the registered contents
+	suite.NoError(suite.registry.Set("static.limits.rate", 30))
+	suite.NoError(suite.registry.RefreshSection("static"))
+	rate, err := suite.registry.GetInt("static.limits.rate")
+	suite.NoError(err)
+	suite.Equal(10, rate)
+
+	// Test a nil map registers an empty section
+	suite.registry.RegisterMap("empty_static", nil)
+	value, err := suite.registry.Get("empty_static")
+	suite.NoError(err)
+	suite.Equal(map[string]interface{}{}, value)
+}
+
+func (suite *ConfigTestSuite) TestFlatten() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+	registry.RegisterMap("database", map[string]interface{}{
+		"host": "localhost",
+		"options": map[string]interface{}{
+			"debug_mode": true,
+			"tags":       []string{"a", "b"},
+		},
+		"hosts": map[string]interface{}{
+			"api.example.com": 8080,
+		},
+	})
+	registry.RegisterMap("empty", nil)
+
+	flat := registry.Flatten()
+	suite.Equal(map[string]interface{}{
+		"database.host":                    "localhost",
+		"database.options.debug_mode":      true,
+		"database.options.tags":            []string{"a", "b"},
+		`database.hosts.api\.example\.com`: 8080,
+		"empty":                            map[string]interface{}{},
+	}, flat)
+
+	// Test every path resolves with Get
+	for path, expected := range flat {
+		value, err := registry.Get(path)
+		suite.NoError(err, path)
+		suite.Equal(expected, value, path)
+	}
+
+	// Test values are copies
+	flat["database.options.tags"].([]string)[0] = "changed"
+	tags, err := registry.GetStringArray("database.options.tags")
+	suite.NoError(err)
+	suite.Equal([]string{"a", "b"}, tags)
+}
+
+func (suite *ConfigTestSuite) TestRegisterReader() {
+	inputs := map[string]string{
+		"json": `{"name": "api", "port": 8080, "hosts": ["a", "b"], "db": {"pool": 4}}`,
+		"yaml": "name: api\nport: 8080\nhosts: [a, b]\ndb:\n  pool: 4\n",
+		"toml": "name = \"api\"\nport = 8080\nhosts = [\"a\", \"b\"]\n[db]\npool = 4\n",
+	}
+
+	for format, input := range inputs {
+		section := "reader_" + format
+		suite.NoError(suite.registry.RegisterReader(section, format, strings.NewReader(input)), format)
+
+		name, err := suite.registry.GetString(section + ".name")
+		suite.NoError(err, format)
+		suite.Equal("api", name, format)
+		port, err := suite.registry.GetInt(section + ".port")
+		suite.NoError(err, format)
+		suite.Equal(8080, port, format)
+		hosts, err := suite.registry.GetStringArray(section + ".hosts")
+		suite.NoError(err, format)
+		suite.Equal([]string{"a", "b"}, hosts, format)
+		pool, err := suite.registry.GetInt(section + ".db.pool")
+		suite.NoError(err, format)
+		suite.Equal(4, pool, format)
+
+		// Test Refresh keeps the parsed contents
+		suite.NoError(suite.registry.Set(section+".port", 9090))
+		suite.NoError(suite.registry.RefreshSection(section))
+		port, err = suite.registry.GetInt(section + ".port")
+		suite.NoError(err, format)
+		suite.Equal(8080, port, format)
+	}
+
+	// Test invalid input and unknown formats register nothing
+	suite.Error(suite.registry.RegisterReader("reader_invalid", "json", strings.NewReader("{")))
+	suite.Error(suite.registry.RegisterReader("reader_invalid", "ini", strings.NewReader("a=b")))
+	_, err := suite.registry.Get("reader_invalid.a")
+	suite.True(errors.Is(err, gonfig.ErrSectionNotFound))
+}
+
+func (suite *ConfigTestSuite) TestLayers() {
+	override := map[string]interface{}{
+		"database": map[string]interface{}{"host": "db.override"},
+	}
+	suite.registry.AddLayer("layered", 0, func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{
+			"name": "base",
+			"database": map[string]interface{}{
+				"host": "localhost",
+				"port": 5432,
+			},
+		}
+	})
+	suite.registry.AddLayer("layered", 100, func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return override
+	})
+	suite.registry.AddLayer("layered", 10, func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{
+			"name":     "production",
+			"database": map[string]interface{}{"host": "db.internal"},
+		}
+	})
+
+	// Test reads resolve from the highest-priority layer that has the key
+	host, err := suite.registry.GetString("layered.database.host")
+	suite.NoError(err)
+	suite.Equal("db.override", host)
+	name, err := suite.registry.GetString("layered.name")
+	suite.NoError(err)
+	suite.Equal("production", name)
+	port, err := suite.registry.GetInt("layered.database.port")
+	suite.NoError(err)
+	suite.Equal(5432, port)
+
+	// Test Source reports the providing layer
+	layer, ok := suite.registry.Source("layered.database.host")
+	suite.True(ok)
+	suite.Equal(100, layer)
+	layer, ok = suite.registry.Source("layered.name")
+	suite.True(ok)
+	suite.Equal(10, layer)
+	layer, ok = suite.registry.Source("layered.database.port")
+	suite.True(ok)
+	suite.Equal(0, layer)
+	_, ok = suite.registry.Source("layered.missing")
+	suite.False(ok)
+
+	// Test Refresh reloads every layer
+	delete(override, "database")
+	suite.NoError(suite.registry.RefreshSection("layered"))
+	host, err = suite.registry.GetString("layered.database.host")
+	suite.NoError(err)
+	suite.Equal("db.internal", host)
+	layer, ok = suite.registry.Source("layered.database.host")
+	suite.True(ok)
+	suite.Equal(10, layer)
+
+	// Test Register discards the layers
+	suite.registry.Register("layered", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{"name": "plain"}
+	})
+	_, ok = suite.registry.Source("layered.name")
+	suite.False(ok)
+}
+
+func (suite *ConfigTestSuite) TestOrigin() {
+	suite.T().Setenv("GONFIG_ORIGIN_HOST", "db.internal")
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles(), gonfig.WithEnvInterpolation())
+	suite.NoError(err)
+	registry.Register("database", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{
+			"host":    "${GONFIG_ORIGIN_HOST}",
+			"port":    5432,
+			"options": map[string]interface{}{"debug": false, "pool": 4},
+		}
+	})
+
+	assertOrigin := func(path, expected string) {
+		origin, err := registry.Origin(path)
+		suite.NoError(err, path)
+		suite.Equal(expected, origin, path)
+	}
+
+	// Test loaded and interpolated values
+	assertOrigin("database.port", "loader:database")
+	assertOrigin("database.host", "env")
+
+	// Test writes mark their path and everything below it
+	suite.NoError(registry.Set("database.port", 6432))
+	assertOrigin("database.port", "set")
+	registry.MergeFrom(map[string]map[string]interface{}{
+		"database": {"options": map[string]interface{}{"debug": true}},
+	})
+	assertOrigin("database.options.debug", "merge")
+	assertOrigin("database.options.pool", "loader:database")
+	suite.NoError(registry.Set("database.options", map[string]interface{}{"pool": 8}))
+	assertOrigin("database.options.pool", "set")
+
+	// Test snapshots and failed transactions restore origins
+	snap := registry.Snapshot()
+	err = registry.Transaction(func(tx configContracts.ConfigRegistry) error {
+		if err := tx.Set("database.host", "tx.internal"); err != nil {
+			return err
+		}
+		return tx.Set("missing.key", 1)
+	})
+	suite.Error(err)
+	assertOrigin("database.host", "env")
+	suite.NoError(registry.Unset("database.port"))
+	suite.NoError(registry.Restore(snap))
+	assertOrigin("database.port", "set")
+
+	// Test Marshal, layers and reloads
+	suite.NoError(registry.Marshal("marshaled", struct {
+		Name string `config:"name"`
+	}{Name: "api"}))
+	assertOrigin("marshaled.name", "marshal")
+	registry.AddLayer("layered", 5, func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{"name": "api"}
+	})
+	assertOrigin("layered.name", "layer:5")
+	suite.NoError(registry.RefreshSection("database"))
+	assertOrigin("database.port", "loader:database")
+	assertOrigin("database.host", "env")
+
+	// Test missing paths return the lookup error
+	_, err = registry.Origin("database.missing")
+	suite.True(errors.Is(err, gonfig.ErrKeyNotFound))
+}
+
+func (suite *ConfigTestSuite) TestGetGlob() {
+	suite.registry.RegisterMap("mail", map[string]interface{}{
+		"mailers": map[string]interface{}{
+			"smtp":     map[string]interface{}{"transport": "smtp", "port": 587},
+			"ses":      map[string]interface{}{"transport": "ses"},
+			"log":      map[string]interface{}{"channel": "stack"},
+			"sendmail": map[string]interface{}{"transport": "sendmail"},
+		},
+		"relays": []interface{}{
+			map[string]interface{}{"host": "relay-1"},
+			map[string]interface{}{"host": "relay-2"},
+		},
+	})
+
+	// Test wildcards expand against map keys in sorted order, skipping missing paths
+	values, err := suite.registry.GetGlob("mail.mailers.*.transport")
+	suite.NoError(err)
+	suite.Equal([]interface{}{"sendmail", "ses", "smtp"}, values)
+
+	// Test wildcards expand against array indexes
+	values, err = suite.registry.GetGlob("mail.relays.*.host")
+	suite.NoError(err)
+	suite.Equal([]interface{}{"relay-1", "relay-2"}, values)
+
+	// Test no match returns an empty slice
+	values, err = suite.registry.GetGlob("mail.mailers.*.missing")
+	suite.NoError(err)
+	suite.NotNil(values)
+	suite.Empty(values)
+	values, err = suite.registry.GetGlob("nosection.*")
+	suite.NoError(err)
+	suite.Empty(values)
+
+	// Test recursive wildcards are rejected
+	_, err = suite.registry.GetGlob("mail.**.transport")
+	suite.True(errors.Is(err, gonfig.ErrInvalidPath))
+}
+
+func (suite *ConfigTestSuite) TestGetKeys() {
+	suite.registry.RegisterMap("keys", map[string]interface{}{
+		"connections": map[string]interface{}{
+			"pgsql": map[string]interface{}{"port": 5432},
+			"mysql": map[string]interface{}{"port": 3306},
+		},
+		"driver": "mysql",
+	})
+
+	keys, err := suite.registry.GetKeys("keys.connections")
+	suite.NoError(err)
+	suite.Equal([]string{"mysql", "pgsql"}, keys)
+
+	// Test a section name lists the section's keys
+	keys, err = suite.registry.GetKeys("keys")
+	suite.NoError(err)
+	suite.Equal([]string{"connections", "driver"}, keys)
+
+	// Test missing paths and non-map values are errors
+	_, err = suite.registry.GetKeys("keys.missing")
+	suite.True(errors.Is(err, gonfig.ErrKeyNotFound))
+	_, err = suite.registry.GetKeys("keys.driver")
+	suite.True(errors.Is(err, gonfig.ErrTypeConversion))
+}
+
+func (suite *ConfigTestSuite) TestGetMapArray() {
+	suite.registry.RegisterMap("routes", map[string]interface{}{
+		"typed": []map[string]interface{}{
+			{"path": "/", "handler": "home"},
+			{"path": "/users", "handler": "users"},
+		},
+		"loose": []interface{}{
+			map[string]interface{}{"path": "/health"},
+		},
+		"mixed": []interface{}{
+			map[string]interface{}{"path": "/"},
+			"not a map",
+		},
+		"name": "router",
+	})
+
+	routes, err := suite.registry.GetMapArray("routes.typed")
+	suite.NoError(err)
+	suite.Len(routes, 2)
+	suite.Equal("users", routes[1]["handler"])
+
+	routes, err = suite.registry.GetMapArray("routes.loose")
+	suite.NoError(err)
+	suite.Equal([]map[string]interface{}{{"path": "/health"}}, routes)
+
+	// Test traversal descends into typed map arrays
+	handler, err := suite.registry.GetString("routes.typed.1.handler")
+	suite.NoError(err)
+	suite.Equal("users", handler)
+
+	// Test returned maps are copies
+	routes, err = suite.registry.GetMapArray("routes.typed")
+	suite.NoError(err)
+	routes[0]["handler"] = "changed"
+	handler, err = suite.registry.GetString("routes.typed.0.handler")
+	suite.NoError(err)
+	suite.Equal("home", handler)
+
+	// Test errors name the failing index, and defaults apply to missing paths
+	_, err = suite.registry.GetMapArray("routes.mixed")
+	suite.True(errors.Is(err, gonfig.ErrTypeConversion))
+	suite.Contains(err.Error(), "index 1")
+	_, err = suite.registry.GetMapArray("routes.name")
+	suite.True(errors.Is(err, gonfig.ErrTypeConversion))
+	routes, err = suite.registry.GetMapArray("routes.missing", []map[string]interface{}{})
+	suite.NoError(err)
+	suite.Empty(routes)
+}
+
+func (suite *ConfigTestSuite) TestEnvOverride() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+	registry.EnableValueCache(true)
+	registry.RegisterMap("database", map[string]interface{}{
+		"host": "localhost",
+		"port": 5432,
+	})
+
+	registry.EnableEnvOverride("GONFIG_OVERRIDE")
+	suite.T().Setenv("GONFIG_OVERRIDE_DATABASE_HOST", "db.internal")
+	suite.T().Setenv("GONFIG_OVERRIDE_DATABASE_PORT", "6432")
+	suite.T().Setenv("GONFIG_OVERRIDE_DATABASE_USER", "app")
+
+	// Test variables override stored values, and typed accessors convert them
+	host, err := registry.GetString("database.host")
+	suite.NoError(err)
+	suite.Equal("db.internal", host)
+	port, err := registry.GetInt("database.port")
+	suite.NoError(err)
+	suite.Equal(6432, port)
+	origin, err := registry.Origin("database.host")
+	suite.NoError(err)
+	suite.Equal("env", origin)
+
+	// Test variables provide paths that aren't configured
+	user, err := registry.GetString("database.user")
+	suite.NoError(err)
+	suite.Equal("app", user)
+
+	// Test changed variables are picked up despite the value cache
+	suite.T().Setenv("GONFIG_OVERRIDE_DATABASE_PORT", "7432")
+	port, err = registry.GetInt("database.port")
+	suite.NoError(err)
+	suite.Equal(7432, port)
+
+	// Test a trailing underscore in the prefix is ignored, and an empty prefix disables overrides
+	registry.EnableEnvOverride("GONFIG_OVERRIDE_")
+	host, err = registry.GetString("database.host")
+	suite.NoError(err)
+	suite.Equal("db.internal", host)
+	registry.EnableEnvOverride("")
+	host, err = registry.GetString("database.host")
+	suite.NoError(err)
+	suite.Equal("localhost", host)
+}
+
+func (suite *ConfigTestSuite) TestGetStringArraySep() {
+	suite.registry.RegisterMap("lists", map[string]interface{}{
+		"semicolons": "a; b ;c",
+		"spaces":     "a b c",
+		"commas":     "a,b",
+		"slice":      []string{"x", "y"},
+	})
+
+	values, err := suite.registry.GetStringArraySep("lists.semicolons", ";")
+	suite.NoError(err)
+	suite.Equal([]string{"a", "b", "c"}, values)
+
+	values, err = suite.registry.GetStringArraySep("lists.spaces", " ")
+	suite.NoError(err)
+	suite.Equal([]string{"a", "b", "c"}, values)
+
+	// Test an empty separator falls back to the registry's separator
+	values, err = suite.registry.GetStringArraySep("lists.commas", "")
+	suite.NoError(err)
+	suite.Equal([]string{"a", "b"}, values)
+
+	// Test slices are returned as-is and defaults apply to missing paths
+	values, err = suite.registry.GetStringArraySep("lists.slice", ";")
+	suite.NoError(err)
+	suite.Equal([]string{"x", "y"}, values)
+	values, err = suite.registry.GetStringArraySep("lists.missing", ";", []string{"default"})
+	suite.NoError(err)
+	suite.Equal([]string{"default"}, values)
+}
+
+func (suite *ConfigTestSuite) TestGetStringConvertsScalars() {
+	suite.registry.RegisterMap("scalars", map[string]interface{}{
+		"port":    8080,
+		"ratio":   0.5,
+		"enabled": true,
+		"timeout": 90 * time.Second,
+		"hosts":   []string{"a"},
+		"nested":  map[string]interface{}{"key": "value"},
+	})
+
+	for path, expected := range map[string]string{
+		"scalars.port":    "8080",
+		"scalars.ratio":   "0.5",
+		"scalars.enabled": "true",
+		"scalars.timeout": "1m30s",
+	} {
+		value, err := suite.registry.GetString(path)
+		suite.NoError(err, path)
+		suite.Equal(expected, value, path)
+	}
+
+	// Test maps and slices are still rejected
+	_, err := suite.registry.GetString("scalars.hosts")
+	suite.ErrorIs(err, gonfig.ErrTypeConversion)
+	_, err = suite.registry.GetString("scalars.nested")
+	suite.ErrorIs(err, gonfig.ErrTypeConversion)
+}
+
+func (suite *ConfigTestSuite) TestGetIntRejectsFractions() {
+	suite.registry.RegisterMap("counts", map[string]interface{}{
+		"whole":    3.0,
+		"fraction": 3.9,
+		"half":     0.5,
+	})
+
+	value, err := suite.registry.GetInt("counts.whole")
+	suite.NoError(err)
+	suite.Equal(3, value)
+
+	_, err = suite.registry.GetInt("counts.fraction")
+	suite.ErrorIs(err, gonfig.ErrTypeConversion)
+	suite.Contains(err.Error(), "value 3.9 at path counts.fraction is not an integer")
+	_, err = suite.registry.GetInt("counts.half")
+	suite.ErrorIs(err, gonfig.ErrTypeConversion)
+}
+
+func (suite *ConfigTestSuite) TestGetIntOverflow() {
+	suite.registry.RegisterMap("overflow", map[string]interface{}{
+		"max_int64":   strconv.FormatInt(math.MaxInt64, 10),
+		"beyond":      "9223372036854775808",
+		"large_float": 1e19,
+	})
+
+	// Test values that fit the platform int are returned unchanged
+	value, err := suite.registry.GetInt("overflow.max_int64")
+	if strconv.IntSize == 64 {
+		suite.NoError(err)
+		suite.Equal(math.MaxInt, value)
+	} else {
+		suite.ErrorIs(err, gonfig.ErrTypeConversion)
+		suite.Contains(err.Error(), "overflows int")
+	}
+
+	// Test values outside the range are errors instead of wrapping
+	_, err = suite.registry.GetInt("overflow.beyond")
+	suite.ErrorIs(err, gonfig.ErrTypeConversion)
+	suite.Contains(err.Error(), "overflows int")
+	_, err = suite.registry.GetInt("overflow.large_float")
+	suite.ErrorIs(err, gonfig.ErrTypeConversion)
+	suite.Contains(err.Error(), "overflows int")
+}
+
+func (suite *ConfigTestSuite) TestLoadJSONSchema() {
+	schema, err := gonfig.LoadJSONSchema([]byte(`{
+		"type": "object",
+		"properties": {
+			"server": {
+				"type": "object",
+				"required": ["host"],
+				"properties": {
+					"host": {"type": "string"},
+					"port": {"type": "integer", "default": 8080},
+					"ratio": {"type": ["number", "null"]},
+					"origins": {"type": "array", "items": {"type": "string"}},
+					"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+					"any": {"description": "no type, skipped"},
+					"ref": {"$ref": "#/definitions/other"}
+				},
+				"patternProperties": {"^x-": {"type": "string"}}
+			}
+		}
+	}`))
+	suite.NoError(err)
+
+	// Test defaults are applied and types checked
+	config := map[string]interface{}{
+		"server": map[string]interface{}{
+			"host":    "localhost",
+			"ratio":   0.5,
+			"origins": []string{"https://example.com"},
+			"labels":  map[string]interface{}{"team": "core"},
+			"any":     42,
+		},
+	}
+	suite.NoError(schema.Validate(config))
+	suite.Equal(8080, config["server"].(map[string]interface{})["port"])
+
+	// Test required fields, types and element types
+	err = schema.Validate(map[string]interface{}{
+		"server": map[string]interface{}{
+			"port":    "8080",
+			"origins": []interface{}{1},
+			"labels":  map[string]interface{}{"team": 1},
+		},
+	})
+	suite.Error(err)
+	suite.Contains(err.Error(), "required field missing: server.host")
+	suite.Contains(err.Error(), "validation failed for server.port: expected type int, got string")
+	suite.Contains(err.Error(), "validation failed for server.origins: expected element type string at index 0, got int")
+	suite.Contains(err.Error(), "validation failed for server.labels: expected element type string for key 'team', got int")
+
+	// Test invalid documents and defaults
+	_, err = gonfig.LoadJSONSchema([]byte(`{`))
+	suite.Error(err)
+	_, err = gonfig.LoadJSONSchema([]byte(`{"properties": {"app": {"properties": {"port": {"type": "integer", "default": 1.5}}}}}`))
+	suite.Error(err)
+	suite.Contains(err.Error(), "invalid default for 'app.port'")
+
+	// Test integers loaded from JSON, which are stored as float64, match "integer"
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+	suite.NoError(registry.RegisterReader("app", "json", strings.NewReader(`{"port": 8080, "workers": [1, 2], "mode": 2, "ratio": 1}`)))
+	schema, err = gonfig.LoadJSONSchema([]byte(`{"properties": {"app": {"properties": {
+		"port": {"type": "integer", "minimum": 1, "maximum": 65535},
+		"workers": {"type": "array", "items": {"type": "integer"}},
+		"mode": {"type": "integer", "enum": [1, 2]},
+		"ratio": {"type": "number"}
+	}}}}`))
+	suite.NoError(err)
+	suite.NoError(schema.ValidateRegistry(registry))
+
+	suite.NoError(registry.Set("app.port", 80.5))
+	suite.NoError(registry.Set("app.workers", []interface{}{1.0, 2.5}))
+	err = schema.ValidateRegistry(registry)
+	suite.Error(err)
+	suite.Contains(err.Error(), "validation failed for app.port: expected type int, got float64")
+	suite.Contains(err.Error(), "validation failed for app.workers: expected element type int at index 1, got float64")
+}
+
+func (suite *ConfigTestSuite) TestSchemaPattern() {
+	schema := gonfig.NewConfigSchema()
+	schema.AddField("app.version", configContracts.ConfigSchemaField{
+		Type:    reflect.String,
+		Pattern: `^v\d+\.\d+\.\d+$`,
+	})
+	schema.AddField("app.url", configContracts.ConfigSchemaField{
+		Type:    reflect.String,
+		Pattern: `(`,
+	})
+
+	suite.NoError(schema.ValidateField("app.version", "v1.2.3"))
+
+	err := schema.ValidateField("app.version", "1.2")
+	suite.Error(err)
+	suite.Contains(err.Error(), "value does not match pattern")
+
+	// Test invalid patterns fail validation instead of panicking
+	err = schema.ValidateField("app.url", "https://example.com")
+	suite.Error(err)
+	suite.Contains(err.Error(), "invalid pattern")
+
+	// Test JSON Schema patterns
+	schema, err = gonfig.LoadJSONSchema([]byte(`{"properties": {"app": {"properties": {"version": {"type": "string", "pattern": "^v\\d+$"}}}}}`))
+	suite.NoError(err)
+	suite.NoError(schema.ValidateField("app.version", "v1"))
+	suite.Error(schema.ValidateField("app.version", "1"))
+	_, err = gonfig.LoadJSONSchema([]byte(`{"properties": {"app": {"properties": {"version": {"type": "string", "pattern": "("}}}}}`))
+	suite.Error(err)
+}
+
+func (suite *ConfigTestSuite) TestSchemaEnum() {
+	schema := gonfig.NewConfigSchema()
+	schema.AddField("app.log_level", configContracts.ConfigSchemaField{
+		Type: reflect.String,
+		Enum: []interface{}{"debug", "info", "warn", "error"},
+	})
+	schema.AddField("app.workers", configContracts.ConfigSchemaField{
+		Type: reflect.Int,
+		Enum: []interface{}{1, 2, 4},
+	})
+
+	suite.NoError(schema.ValidateField("app.log_level", "warn"))
+	suite.NoError(schema.ValidateField("app.workers", 4))
+
+	err := schema.ValidateField("app.log_level", "trace")
+	suite.Error(err)
+	suite.Contains(err.Error(), "[debug info warn error]")
+	suite.Error(schema.ValidateField("app.workers", 3))
+
+	// Test JSON Schema enums, whose numbers are converted for integer fields
+	schema, err = gonfig.LoadJSONSchema([]byte(`{"properties": {"app": {"properties": {"workers": {"type": "integer", "enum": [1, 2, 4]}}}}}`))
+	suite.NoError(err)
+	suite.NoError(schema.ValidateField("app.workers", 2))
+	suite.Error(schema.ValidateField("app.workers", 3))
+}
+
+func (suite *ConfigTestSuite) TestSchemaBounds() {
+	minimum, maximum := 1.0, 64.0
+	schema := gonfig.NewConfigSchema()
+	schema.AddField("app.workers", configContracts.ConfigSchemaField{
+		Type: reflect.Int,
+		Min:  &minimum,
+		Max:  &maximum,
+	})
+	schema.AddField("app.ratio", configContracts.ConfigSchemaField{
+		Type: reflect.Float64,
+		Max:  &maximum,
+	})
+
+	suite.NoError(schema.ValidateField("app.workers", 1))
+	suite.NoError(schema.ValidateField("app.workers", 64))
+	suite.NoError(schema.ValidateField("app.ratio", -5.5))
+
+	err := schema.ValidateField("app.workers", 0)
+	suite.Error(err)
+	suite.Contains(err.Error(), "value 0 is below minimum 1")
+
+	err = schema.ValidateField("app.ratio", 64.5)
+	suite.Error(err)
+	suite.Contains(err.Error(), "value 64.5 is above maximum 64")
+
+	// Test JSON Schema bounds
+	schema, err = gonfig.LoadJSONSchema([]byte(`{"properties": {"app": {"properties": {"workers": {"type": "integer", "minimum": 1, "maximum": 8}}}}}`))
+	suite.NoError(err)
+	suite.NoError(schema.ValidateField("app.workers", 8))
+	suite.Error(schema.ValidateField("app.workers", 9))
+}
+
+func (suite *ConfigTestSuite) TestRegisterSchemaValidatesRefresh() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+
+	port := 8080
+	registry.Register("server", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{"port": port}
+	})
+
+	maximum := 65535.0
+	schema := gonfig.NewConfigSchema()
+	schema.AddField("server.port", configContracts.ConfigSchemaField{
+		Type:     reflect.Int,
+		Required: true,
+		Max:      &maximum,
+	})
+	schema.AddField("server.host", configContracts.ConfigSchemaField{
+		Type:    reflect.String,
+		Default: "localhost",
+	})
+	registry.RegisterSchema("server", schema)
+
+	// Test a valid reload is applied, with defaults populated
+	port = 9090
+	suite.NoError(registry.Refresh())
+	suite.Equal(9090, registry.MustGetInt("server.port"))
+	suite.Equal("localhost", registry.MustGetString("server.host"))
+
+	// Test an invalid reload is rolled back
+	port = 70000
+	err = registry.RefreshSection("server")
+	suite.Error(err)
+	suite.Contains(err.Error(), "failed validation")
+	suite.Equal(9090, registry.MustGetInt("server.port"))
+
+	suite.Error(registry.Refresh())
+	suite.Equal(9090, registry.MustGetInt("server.port"))
+
+	// Test removing the schema restores unvalidated reloads
+	registry.RegisterSchema("server", nil)
+	suite.NoError(registry.Refresh())
+	suite.Equal(70000, registry.MustGetInt("server.port"))
+}
+
+func (suite *ConfigTestSuite) TestRegisterDecodeHook() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+
+	registry.RegisterMap("server", map[string]interface{}{
+		"addr":     "10.0.0.1",
+		"fallback": "10.0.0.2",
+		"name":     "api",
+		"limits":   map[string]interface{}{"burst": "ten"},
+	})
+
+	var calls []string
+	registry.RegisterDecodeHook(func(from, to reflect.Type, data interface{}) (interface{}, error) {
+		calls = append(calls, to.String())
+		return data, nil
+	})
+	registry.RegisterDecodeHook(func(from, to reflect.Type, data interface{}) (interface{}, error) {
+		if from.Kind() != reflect.String || to != reflect.TypeOf(net.IP{}) {
+			return data, nil
+		}
+		ip := net.ParseIP(data.(string))
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %q", data)
+		}
+		return ip, nil
+	})
+	registry.RegisterDecodeHook(func(from, to reflect.Type, data interface{}) (interface{}, error) {
+		if to.Kind() == reflect.Int && data == "ten" {
+			return 10, nil
+		}
+		return data, nil
+	})
+
+	var cfg struct {
+		Addr     net.IP  `config:"addr"`
+		Fallback *net.IP `config:"fallback"`
+		Name     string  `config:"name"`
+		Limits   struct {
+			Burst int `config:"burst"`
+		} `config:"limits"`
+	}
+	suite.NoError(registry.Unmarshal("server", &cfg))
+	suite.Equal("10.0.0.1", cfg.Addr.String())
+	suite.Equal("10.0.0.2", cfg.Fallback.String())
+	suite.Equal("api", cfg.Name)
+	suite.Equal(10, cfg.Limits.Burst)
+	suite.Contains(calls, "net.IP")
+	suite.Contains(calls, "int")
+
+	// Test hook errors fail the field
+	suite.NoError(registry.Set("server.addr", "not-an-ip"))
+	err = registry.Unmarshal("server", &cfg)
+	suite.Error(err)
+	suite.Contains(err.Error(), "invalid IP address")
+
+	// Test hooks apply to UnmarshalKey and are carried over by Clone
+	var limits struct {
+		Burst int `config:"burst"`
+	}
+	suite.NoError(registry.Clone().UnmarshalKey("server.limits", &limits))
+	suite.Equal(10, limits.Burst)
+}
+
+func (suite *ConfigTestSuite) TestSetWeaklyTypedInput() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+
+	registry.RegisterMap("app", map[string]interface{}{
+		"port":  "8080",
+		"debug": "1",
+		"ratio": 1.5,
+		"hosts": "a,b",
+	})
+
+	type appConfig struct {
+		Port  int      `config:"port"`
+		Debug bool     `config:"debug"`
+		Ratio int      `config:"ratio"`
+		Hosts []string `config:"hosts"`
+	}
+
+	// Test weak typing is the default
+	var lenient appConfig
+	suite.NoError(registry.Unmarshal("app", &lenient))
+	suite.Equal(appConfig{Port: 8080, Debug: true, Ratio: 1, Hosts: []string{"a", "b"}}, lenient)
+
+	registry.SetWeaklyTypedInput(false)
+	for _, key := range []string{"port", "debug", "ratio", "hosts"} {
+		var strict appConfig
+		err := registry.Unmarshal("app", &strict)
+		suite.Error(err)
+		suite.Contains(err.Error(), "weakly typed input disabled")
+		suite.Contains(err.Error(), "'"+key+"'")
+
+		// Fix the offending key so the next one is reported
+		switch key {
+		case "port":
+			suite.NoError(registry.Set("app.port", 8080.0))
+		case "debug":
+			suite.NoError(registry.Set("app.debug", true))
+		case "ratio":
+			suite.NoError(registry.Set("app.ratio", 2))
+		case "hosts":
+			suite.NoError(registry.Set("app.hosts", []interface{}{"a", "b"}))
+		}
+	}
+
+	var strict appConfig
+	suite.NoError(registry.Unmarshal("app", &strict))
+	suite.Equal(appConfig{Port: 8080, Debug: true, Ratio: 2, Hosts: []string{"a", "b"}}, strict)
+}
+
+func (suite *ConfigTestSuite) TestRequireSections() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+
+	registry.RegisterMap("app", map[string]interface{}{"name": "MyApp"})
+	registry.RegisterMap("database", map[string]interface{}{})
+
+	suite.NoError(registry.RequireSections())
+	suite.NoError(registry.RequireSections("app", "database"))
+	suite.NoError(registry.ReadOnly().RequireSections("app"))
+
+	err = registry.RequireSections("app", "cache", "queue")
+	suite.Error(err)
+	suite.True(errors.Is(err, gonfig.ErrSectionNotFound))
+	suite.Contains(err.Error(), "'cache'")
+	suite.Contains(err.Error(), "'queue'")
+	suite.NotContains(err.Error(), "'app'")
+}
+
+func (suite *ConfigTestSuite) TestGetStruct() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+
+	registry.RegisterMap("database", map[string]interface{}{
+		"host": "localhost",
+		"port": "5432",
+	})
+
+	type databaseConfig struct {
+		Host string `config:"host"`
+		Port int    `config:"port"`
+	}
+	db, err := gonfig.GetStruct[databaseConfig](registry, "database")
+	suite.NoError(err)
+	suite.Equal(databaseConfig{Host: "localhost", Port: 5432}, db)
+
+	// Test missing sections and required fields propagate Unmarshal errors
+	_, err = gonfig.GetStruct[databaseConfig](registry, "missing")
+	suite.True(errors.Is(err, gonfig.ErrSectionNotFound))
+
+	type requiredConfig struct {
+		User string `config:"user" required:"true"`
+	}
+	_, err = gonfig.GetStruct[requiredConfig](registry, "database")
+	suite.True(errors.Is(err, gonfig.ErrKeyNotFound))
+
+	// Test conversion errors return the zero value
+	suite.NoError(registry.Set("database.port", "not-a-port"))
+	db, err = gonfig.GetStruct[databaseConfig](registry, "database")
+	suite.Error(err)
+	suite.Equal(databaseConfig{}, db)
+
+	// Test non-struct types are rejected
+	_, err = gonfig.GetStruct[*databaseConfig](registry, "database")
+	suite.True(errors.Is(err, gonfig.ErrTypeConversion))
+}
+
+func (suite *ConfigTestSuite) TestForEach() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+
+	registry.RegisterMap("plugins", map[string]interface{}{
+		"metrics": map[string]interface{}{"enabled": true},
+		"auth":    map[string]interface{}{"enabled": false},
+		"cache":   "redis",
+	})
+
+	var keys []string
+	suite.NoError(registry.ForEach("plugins", func(key string, value interface{}) error {
+		keys = append(keys, key)
+		// Test values are copies
+		if m, ok := value.(map[string]interface{}); ok {
+			m["enabled"] = "changed"
+		}
+		return nil
+	}))
+	suite.Equal([]string{"auth", "cache", "metrics"}, keys)
+	suite.Equal(true, registry.MustGetBool("plugins.metrics.enabled"))
+
+	// Test the first error stops the iteration
+	stop := errors.New("stop")
+	keys = nil
+	err = registry.ReadOnly().ForEach("plugins", func(key string, value interface{}) error {
+		keys = append(keys, key)
+		if key == "cache" {
+			return stop
+		}
+		return nil
+	})
+	suite.Equal(stop, err)
+	suite.Equal([]string{"auth", "cache"}, keys)
+
+	err = registry.ForEach("missing", func(string, interface{}) error { return nil })
+	suite.True(errors.Is(err, gonfig.ErrSectionNotFound))
+}
+
+func (suite *ConfigTestSuite) TestDeterministicOrdering() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+
+	registry.RegisterMap("app", map[string]interface{}{
+		"name":     "MyApp",
+		"database": map[string]interface{}{"port": 5432, "host": "localhost"},
+		"a.b":      true,
+	})
+	registry.RegisterMap("cache", map[string]interface{}{"ttl": 60})
+
+	expected := []string{"app.a\\.b", "app.database.host", "app.database.port", "app.name", "cache.ttl"}
+	for i := 0; i < 10; i++ {
+		suite.Equal(expected, registry.AllKeys())
+	}
+	suite.Equal(expected, registry.ReadOnly().AllKeys())
+
+	// Test refresh failures are reported in section order
+	for _, name := range []string{"zeta", "beta", "alpha"} {
+		registry.Register(name, func(configContracts.ConfigRegistry) map[string]interface{} {
+			panic(name)
+		})
+	}
+	err = registry.Refresh()
+	suite.Error(err)
+	message := err.Error()
+	suite.Less(strings.Index(message, "'alpha'"), strings.Index(message, "'beta'"))
+	suite.Less(strings.Index(message, "'beta'"), strings.Index(message, "'zeta'"))
+}
+
+func (suite *ConfigTestSuite) TestTemplateSections() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+
+	host := "db.internal"
+	registry.Register("database", func(configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{"host": host, "port": 5432, "name": "app"}
+	})
+	registry.RegisterMap("app", map[string]interface{}{
+		"dsn":     "postgres://{{ .app.address }}/{{ .database.name }}",
+		"address": "{{ .database.host }}:{{ .database.port }}",
+		"hosts":   []interface{}{"{{ .database.host }}", "cache"},
+		"plain":   "{ not a template }",
+	})
+
+	// Test existing contents are rendered on registration, dependencies first
+	suite.NoError(registry.RegisterTemplateSection("app"))
+	suite.Equal("postgres://db.internal:5432/app", registry.MustGetString("app.dsn"))
+	suite.Equal([]string{"db.internal", "cache"}, registry.MustGetStringArray("app.hosts"))
+	suite.Equal("{ not a template }", registry.MustGetString("app.plain"))
+
+	// Test Refresh re-renders templates against the reloaded sections
+	host = "db2.internal"
+	suite.NoError(registry.Refresh())
+	suite.Equal("postgres://db2.internal:5432/app", registry.MustGetString("app.dsn"))
+
+	// Test cycles and missing keys are errors
+	suite.NoError(registry.RegisterTemplateSection("cyclic"))
+	err = registry.RegisterE("cyclic", func(configContracts.ConfigRegistry) (map[string]interface{}, error) {
+		return map[string]interface{}{
+			"a": "{{ .cyclic.b }}",
+			"b": "{{ .cyclic.a }}",
+		}, nil
+	})
+	suite.Error(err)
+	suite.Contains(err.Error(), "template cycle: cyclic.a -> cyclic.b -> cyclic.a")
+
+	suite.NoError(registry.RegisterTemplateSection("broken"))
+	err = registry.RegisterE("broken", func(configContracts.ConfigRegistry) (map[string]interface{}, error) {
+		return map[string]interface{}{"url": "{{ .database.missing }}"}, nil
+	})
+	suite.Error(err)
+	suite.Contains(err.Error(), "failed to render template at 'broken.url'")
+
+	suite.True(errors.Is(registry.ReadOnly().RegisterTemplateSection("app"), gonfig.ErrReadOnly))
+}
+
+func (suite *ConfigTestSuite) TestSeal() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+
+	registry.RegisterMap("app", map[string]interface{}{"name": "MyApp", "hosts": "a,b"})
+	suite.False(registry.IsSealed())
+
+	registry.Seal()
+	suite.True(registry.IsSealed())
+	suite.True(registry.ReadOnly().IsSealed())
+
+	// Test every mutation is rejected
+	sealed := []error{
+		registry.Set("app.name", "Other"),
+		registry.SetMany(map[string]interface{}{"app.name": "Other"}),
+		registry.Unset("app.name"),
+		registry.RegisterE("cache", func(configContracts.ConfigRegistry) (map[string]interface{}, error) {
+			return map[string]interface{}{}, nil
+		}),
+		registry.RegisterReader("cache", "json", strings.NewReader(`{}`)),
+		registry.Refresh(),
+		registry.RefreshSection("app"),
+		registry.Restore(registry.Snapshot()),
+		registry.Marshal("app", struct{}{}),
+		registry.Transaction(func(tx configContracts.ConfigRegistry) error {
+			return tx.Set("app.name", "Other")
+		}),
+	}
+	for _, err := range sealed {
+		suite.True(errors.Is(err, gonfig.ErrSealed), "%v", err)
+	}
+	_, err = registry.GetOrSet("app.port", 8080)
+	suite.True(errors.Is(err, gonfig.ErrSealed))
+
+	registry.Register("cache", func(configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{}
+	})
+	registry.MergeFrom(map[string]map[string]interface{}{"app": {"name": "Other"}})
+	registry.AddLayer("app", 1, func(configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{"name": "Other"}
+	})
+
+	// Test settings that change what reads return are rejected as well
+	suite.True(errors.Is(registry.SetFallback("app.missing", "app.name"), gonfig.ErrSealed))
+	suite.T().Setenv("GONFIG_SEALED_APP_NAME", "Env")
+	registry.EnableEnvOverride("GONFIG_SEALED")
+	flags := pflag.NewFlagSet("sealed", pflag.ContinueOnError)
+	flags.String("name", "", "")
+	suite.NoError(flags.Parse([]string{"--name=Flag"}))
+	registry.(*gonfig.ConfigRegistry).BindPFlag("app.name", flags.Lookup("name"))
+	registry.EnforceType("app.name", reflect.Int)
+	schema := gonfig.NewConfigSchema()
+	schema.AddField("app.missing", configContracts.ConfigSchemaField{Type: reflect.String, Default: "default"})
+	registry.AttachSchema(schema)
+	registry.RegisterSchema("app", schema)
+	registry.SetArraySeparator(";")
+
+	_, _, err = registry.Resolve("app.missing")
+	suite.True(errors.Is(err, gonfig.ErrKeyNotFound))
+	hosts, err := registry.GetStringArray("app.hosts")
+	suite.NoError(err)
+	suite.Equal([]string{"a", "b"}, hosts)
+
+	// Test reads keep working and nothing changed
+	suite.Equal("MyApp", registry.MustGetString("app.name"))
+	value, err := registry.GetOrSet("app.name", "Other")
+	suite.NoError(err)
+	suite.Equal("MyApp", value)
+	suite.NoError(registry.RequireSections("app"))
+	suite.Error(registry.RequireSections("cache"))
+
+	// Test clones are not sealed
+	clone := registry.Clone()
+	suite.False(clone.IsSealed())
+	suite.NoError(clone.Set("app.name", "Other"))
+}
+
+func (suite *ConfigTestSuite) TestGetStringArrayNumeric() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+
+	registry.RegisterMap("lists", map[string]interface{}{
+		"ints":   []int{1, 2, 3},
+		"int64s": []int64{10, 20},
+		"floats": []float64{0.5, 2},
+		"mixed":  []interface{}{"a", 1, 2.5},
+		"maps":   []interface{}{map[string]interface{}{}},
+	})
+
+	values, err := registry.GetStringArray("lists.ints")
+	suite.NoError(err)
+	suite.Equal([]string{"1", "2", "3"}, values)
+
+	values, err = registry.GetStringArray("lists.int64s")
+	suite.NoError(err)
+	suite.Equal([]string{"10", "20"}, values)
+
+	values, err = registry.GetStringArray("lists.floats")
+	suite.NoError(err)
+	suite.Equal([]string{"0.5", "2"}, values)
+
+	values, err = registry.GetStringArray("lists.mixed")
+	suite.NoError(err)
+	suite.Equal([]string{"a", "1", "2.5"}, values)
+
+	_, err = registry.GetStringArray("lists.maps")
+	suite.True(errors.Is(err, gonfig.ErrTypeConversion))
+	suite.Contains(err.Error(), "index 0")
+
+	suite.Equal([]string{"1", "2", "3"}, suite.registry.MustGetStringArray("test.nested.deep.deeper.numbers"))
+}
+
+func (suite *ConfigTestSuite) TestDiff() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+
+	registry.RegisterMap("app", map[string]interface{}{
+		"name":  "MyApp",
+		"port":  8080,
+		"hosts": []string{"a", "b"},
+		"db":    map[string]interface{}{"host": "localhost", "user": "admin"},
+	})
+	before := registry.Snapshot()
+
+	suite.NoError(registry.SetMany(map[string]interface{}{
+		"app.port":    9090,
+		"app.hosts":   []string{"a", "c"},
+		"app.debug":   true,
+		"app.db.host": "localhost",
+	}))
+	suite.NoError(registry.Unset("app.db.user"))
+	registry.MergeFrom(map[string]map[string]interface{}{"cache": {"ttl": 60}})
+
+	changes := gonfig.Diff(before, registry.Snapshot())
+	suite.Equal([]gonfig.ConfigChange{
+		{Path: "app.db.user", Kind: gonfig.ChangeRemoved, Old: "admin"},
+		{Path: "app.debug", Kind: gonfig.ChangeAdded, New: true},
+		{Path: "app.hosts", Kind: gonfig.ChangeModified, Old: []string{"a", "b"}, New: []string{"a", "c"}},
+		{Path: "app.port", Kind: gonfig.ChangeModified, Old: 8080, New: 9090},
+		{Path: "cache.ttl", Kind: gonfig.ChangeAdded, New: 60},
+	}, changes)
+
+	suite.Empty(gonfig.Diff(before, before))
+	suite.Len(gonfig.Diff(nil, before), 5)
+}
+
+func (suite *ConfigTestSuite) TestRegisterRejectsDottedSectionNames() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+
+	err = registry.RegisterE("foo.bar", func(configContracts.ConfigRegistry) (map[string]interface{}, error) {
+		return map[string]interface{}{"key": "value"}, nil
+	})
+	suite.Error(err)
+	suite.True(errors.Is(err, gonfig.ErrInvalidPath))
+	suite.Contains(err.Error(), "invalid section name 'foo.bar'")
+
+	registry.Register("foo.bar", func(configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{"key": "value"}
+	})
+	registry.AddLayer("foo.bar", 0, func(configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{"key": "value"}
+	})
+	suite.Error(registry.RegisterReader("foo.bar", "json", strings.NewReader(`{}`)))
+	suite.Error(registry.RequireSections("foo.bar"))
+	_, err = registry.Get("foo.bar.key")
+	suite.True(errors.Is(err, gonfig.ErrSectionNotFound))
+}
+
+func (suite *ConfigTestSuite) TestNilSection() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+
+	registry.Register("empty", func(configContracts.ConfigRegistry) map[string]interface{} {
+		return nil
+	})
+
+	// Test every accessor reports ErrNilSection, which is also ErrSectionNotFound
+	_, err = registry.Get("empty.key")
+	suite.True(errors.Is(err, gonfig.ErrNilSection))
+	suite.True(errors.Is(err, gonfig.ErrSectionNotFound))
+	_, err = registry.GetString("empty.key")
+	suite.True(errors.Is(err, gonfig.ErrNilSection))
+	_, err = registry.GetInt("empty.key")
+	suite.True(errors.Is(err, gonfig.ErrNilSection))
+	_, err = registry.GetBool("empty.key")
+	suite.True(errors.Is(err, gonfig.ErrNilSection))
+	_, err = registry.GetFloat("empty.key")
+	suite.True(errors.Is(err, gonfig.ErrNilSection))
+	_, err = registry.GetStringArray("empty.key")
+	suite.True(errors.Is(err, gonfig.ErrNilSection))
+	_, err = registry.GetIntArray("empty.key")
+	suite.True(errors.Is(err, gonfig.ErrNilSection))
+	_, err = registry.GetKeys("empty")
+	suite.True(errors.Is(err, gonfig.ErrNilSection))
+	suite.True(errors.Is(registry.Unset("empty.key"), gonfig.ErrNilSection))
+	suite.True(errors.Is(registry.ForEach("empty", func(string, interface{}) error { return nil }), gonfig.ErrNilSection))
+
+	// Test missing sections are not reported as nil
+	_, err = registry.Get("missing.key")
+	suite.True(errors.Is(err, gonfig.ErrSectionNotFound))
+	suite.False(errors.Is(err, gonfig.ErrNilSection))
+
+	// Test defaults are returned for nil sections
+	str, err := registry.GetString("empty.key", "fallback")
+	suite.NoError(err)
+	suite.Equal("fallback", str)
+	n, err := registry.GetInt("empty.key", 42)
+	suite.NoError(err)
+	suite.Equal(42, n)
+	b, err := registry.GetBool("empty.key", true)
+	suite.NoError(err)
+	suite.True(b)
+	f, err := registry.GetFloat("empty.key", 1.5)
+	suite.NoError(err)
+	suite.Equal(1.5, f)
+	list, err := registry.GetStringArray("empty.key", []string{"a"})
+	suite.NoError(err)
+	suite.Equal([]string{"a"}, list)
+	port, err := gonfig.Get[int](registry, "empty.port", 8080)
+	suite.NoError(err)
+	suite.Equal(8080, port)
+}
+
+func (suite *ConfigTestSuite) TestEnforceType() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+
+	registry.RegisterMap("database", map[string]interface{}{
+		"port": "5432",
+		"host": "localhost",
+	})
+
+	// Test values are converted until a kind is enforced
+	str, err := registry.GetString("database.port")
+	suite.NoError(err)
+	suite.Equal("5432", str)
+
+	registry.EnforceType("database.port", reflect.Int)
+	_, err = registry.GetString("database.port")
+	suite.True(errors.Is(err, gonfig.ErrTypeConversion))
+	suite.Contains(err.Error(), "value at 'database.port' must be of kind int: found type string")
+	_, err = registry.GetInt("database.port")
+	suite.True(errors.Is(err, gonfig.ErrTypeConversion))
+	_, err = registry.Get(`database["port"]`)
+	suite.True(errors.Is(err, gonfig.ErrTypeConversion))
+	_, err = registry.Clone().Get("database.port")
+	suite.True(errors.Is(err, gonfig.ErrTypeConversion))
+
+	// Test defaults don't hide a mismatch, only a missing value
+	_, err = registry.GetString("database.port", "default")
+	suite.True(errors.Is(err, gonfig.ErrTypeConversion))
+	_, err = registry.GetIntArray("database.port", []int{1})
+	suite.True(errors.Is(err, gonfig.ErrTypeConversion))
+	port, err := registry.GetInt("database.missing", 1)
+	suite.NoError(err)
+	suite.Equal(1, port)
+
+	// Test values of the enforced kind read normally
+	suite.NoError(registry.Set("database.port", 5432))
+	str, err = registry.GetString("database.port")
+	suite.NoError(err)
+	suite.Equal("5432", str)
+	suite.Equal("localhost", registry.MustGetString("database.host"))
+
+	// Test enforcement can be removed
+	suite.NoError(registry.Set("database.port", "5433"))
+	registry.EnforceType("database.port", reflect.Invalid)
+	suite.Equal(5433, registry.MustGetInt("database.port"))
+}
+
+func (suite *ConfigTestSuite) TestReturnedSlicesAreCopies() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+	registry.EnableValueCache(true)
+
+	hosts := make([]string, 2, 10)
+	copy(hosts, []string{"a", "b"})
+	registry.RegisterMap("app", map[string]interface{}{
+		"hosts": hosts,
+		"ports": []interface{}{80, 443},
+	})
+
+	// Test appending and writing to returned slices doesn't affect stored values,
+	// even when the stored slice has spare capacity
+	list, err := registry.GetStringArray("app.hosts")
+	suite.NoError(err)
+	list[0] = "changed"
+	_ = append(list, "appended")
+
+	value, err := registry.Get("app.hosts")
+	suite.NoError(err)
+	stored := value.([]string)
+	stored[1] = "changed"
+	_ = append(stored[:1], "appended")
+
+	ports, err := registry.Get("app.ports")
+	suite.NoError(err)
+	ports.([]interface{})[0] = 8080
+
+	list, err = registry.GetStringArray("app.hosts")
+	suite.NoError(err)
+	suite.Equal([]string{"a", "b"}, list)
+	intList, err := registry.GetIntArray("app.ports")
+	suite.NoError(err)
+	suite.Equal([]int{80, 443}, intList)
+
+	// Test the caller's original slice is not shared either
+	hosts[0] = "changed"
+	suite.Equal([]string{"a", "b"}, registry.MustGetStringArray("app.hosts"))
+}
+
+func (suite *ConfigTestSuite) TestLoadDir() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+
+	dir := suite.T().TempDir()
+	write := func(name, content string) {
+		suite.NoError(os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	write("app.yaml", "name: api\nport: 8080\n")
+	write("database.yml", "host: localhost\n")
+	write("broken.yaml", "name: [unclosed\n")
+	write("notes.txt", "ignored")
+
+	// Test a broken file is reported without stopping the others from loading
+	err = registry.LoadDir(dir, "yaml")
+	suite.Error(err)
+	suite.Contains(err.Error(), "broken")
+	suite.Equal(8080, registry.MustGetInt("app.port"))
+	suite.Equal("localhost", registry.MustGetString("database.host"))
+	suite.NoError(registry.RequireSections("broken"))
+	suite.Error(registry.RequireSections("notes"))
+
+	// Test Refresh re-reads changed files and registers new ones
+	write("app.yaml", "name: api\nport: 9090\n")
+	write("broken.yaml", "name: fixed\n")
+	write("cache.yaml", "driver: redis\n")
+	suite.NoError(registry.Refresh())
+	suite.Equal(9090, registry.MustGetInt("app.port"))
+	suite.Equal("fixed", registry.MustGetString("broken.name"))
+	suite.Equal("redis", registry.MustGetString("cache.driver"))
+
+	// Test unsupported formats and missing directories are errors
+	suite.Error(registry.LoadDir(dir, "ini"))
+	suite.Error(registry.LoadDir(filepath.Join(dir, "missing"), "yaml"))
+}
+
+func (suite *ConfigTestSuite) TestResolve() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+	registry.RegisterMap("database", map[string]interface{}{
+		"host": "localhost",
+		"user": "app",
+		"name": "main",
+	})
+	schema := gonfig.NewConfigSchema()
+	schema.AddField("database.port", configContracts.ConfigSchemaField{Type: reflect.Int, Default: 5432})
+	schema.AddField("database.host", configContracts.ConfigSchemaField{Type: reflect.String, Default: "default.internal"})
+	registry.RegisterSchema("database", schema)
+
+	registry.EnableEnvOverride("GONFIG_RESOLVE")
+	suite.T().Setenv("GONFIG_RESOLVE_DATABASE_HOST", "env.internal")
+	suite.T().Setenv("GONFIG_RESOLVE_DATABASE_USER", "env_user")
+
+	// Test loader values and schema defaults
+	value, origin, err := registry.Resolve("database.name")
+	suite.NoError(err)
+	suite.Equal("main", value)
+	suite.Equal("loader:database", origin)
+	value, origin, err = registry.Resolve("database.port")
+	suite.NoError(err)
+	suite.Equal(5432, value)
+	suite.Equal("default", origin)
+	_, err = registry.Get("database.port")
+	suite.True(errors.Is(err, gonfig.ErrKeyNotFound))
+
+	// Test environment overrides win over loader values and schema defaults
+	value, origin, err = registry.Resolve("database.host")
+	suite.NoError(err)
+	suite.Equal("env.internal", value)
+	suite.Equal("env", origin)
+
+	// Test values written with Set win over environment overrides, in Get as well
+	suite.NoError(registry.Set("database.user", "set_user"))
+	value, origin, err = registry.Resolve("database.user")
+	suite.NoError(err)
+	suite.Equal("set_user", value)
+	suite.Equal("set", origin)
+	suite.Equal("set_user", registry.MustGetString("database.user"))
+
+	// Test reloading the section restores the override
+	suite.NoError(registry.RefreshSection("database"))
+	suite.Equal("env_user", registry.MustGetString("database.user"))
+	origin, err = registry.Origin("database.user")
+	suite.NoError(err)
+	suite.Equal("env", origin)
+
+	// Test missing paths without a default are errors
+	_, _, err = registry.Resolve("database.missing")
+	suite.True(errors.Is(err, gonfig.ErrKeyNotFound))
+}
+
+func (suite *ConfigTestSuite) TestSchemaParseAs() {
+	minTimeout := float64(time.Second)
+	schema := gonfig.NewConfigSchema()
+	schema.AddField("http.timeout", configContracts.ConfigSchemaField{
+		ParseAs: configContracts.FormatDuration,
+		Default: "30s",
+		Min:     &minTimeout,
+	})
+	schema.AddField("http.max_body", configContracts.ConfigSchemaField{
+		Type:    reflect.Int64,
+		ParseAs: configContracts.FormatByteSize,
+		Default: "10MB",
+	})
+
+	// Test defaults are validated, populated as written and read by the typed accessors
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+	registry.RegisterMap("http", map[string]interface{}{})
+	suite.NoError(schema.ValidateRegistry(registry))
+	timeout, err := registry.GetDuration("http.timeout")
+	suite.NoError(err)
+	suite.Equal(30*time.Second, timeout)
+	maxBody, err := registry.GetBytes("http.max_body")
+	suite.NoError(err)
+	suite.Equal(int64(10_000_000), maxBody)
+
+	// Test values are parsed before bounds are checked, and integers are accepted
+	suite.NoError(schema.ValidateField("http.timeout", "1m30s"))
+	suite.NoError(schema.ValidateField("http.max_body", 512))
+	err = schema.ValidateField("http.timeout", "500ms")
+	suite.Error(err)
+	suite.Contains(err.Error(), "below minimum")
+
+	// Test bad units are rejected, in values and defaults
+	err = schema.ValidateField("http.timeout", "30 parsecs")
+	suite.Error(err)
+	suite.Contains(err.Error(), "invalid duration")
+	err = schema.ValidateField("http.max_body", "10XB")
+	suite.Error(err)
+	suite.Contains(err.Error(), "invalid byte size")
+
+	broken := gonfig.NewConfigSchema()
+	broken.AddField("http.timeout", configContracts.ConfigSchemaField{
+		ParseAs: configContracts.FormatDuration,
+		Default: "soon",
+	})
+	err = broken.Validate(map[string]interface{}{"http": map[string]interface{}{}})
+	suite.Error(err)
+	suite.Contains(err.Error(), "invalid default for http.timeout")
+
+	// Test duration fields built from structs accept strings
+	type HTTPConfig struct {
+		HTTP struct {
+			Timeout time.Duration `config:"timeout" default:"5s"`
+		} `config:"http"`
+	}
+	structSchema, err := gonfig.SchemaFromStruct(HTTPConfig{})
+	suite.NoError(err)
+	suite.NoError(structSchema.Validate(map[string]interface{}{
+		"http": map[string]interface{}{"timeout": "2m"},
+	}))
+
+	// Test GetDuration reports values that aren't durations
+	registry.RegisterMap("app", map[string]interface{}{"timeout": "later"})
+	_, err = registry.GetDuration("app.timeout")
+	suite.True(errors.Is(err, gonfig.ErrTypeConversion))
+	timeout, err = registry.GetDuration("app.missing", time.Minute)
+	suite.NoError(err)
+	suite.Equal(time.Minute, timeout)
+}
+
+func (suite *ConfigTestSuite) TestLoaderReadsOtherSection() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+	registry.RegisterMap("database", map[string]interface{}{"host": "db.internal", "port": 5432})
+
+	loads := 0
+	derive := func() error {
+		return registry.RegisterE("dsn", func(registry configContracts.ConfigRegistry) (map[string]interface{}, error) {
+			loads++
+			host, err := registry.GetString("database.host")
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{
+				"url": fmt.Sprintf("postgres://%s:%d", host, registry.MustGetInt("database.port")),
+			}, nil
+		})
+	}
+
+	// Test registering, refreshing and reloading a section whose loader reads another
+	// section doesn't deadlock
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		suite.NoError(derive())
+		suite.NoError(registry.Set("database.host", "db.other"))
+		suite.NoError(registry.RefreshSection("dsn"))
+		suite.Equal("postgres://db.other:5432", registry.MustGetString("dsn.url"))
+		suite.NoError(registry.Refresh())
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		suite.FailNow("loader reading the registry deadlocked")
+	}
+
+	// Test Refresh reloads database first, so dsn reads the reset host
+	suite.Equal(3, loads)
+	suite.Equal("postgres://db.internal:5432", registry.MustGetString("dsn.url"))
+}
+
+func (suite *ConfigTestSuite) TestLoadersUseRegistry() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+	suite.T().Setenv("GONFIG_LOADER_REGION", "eu-west")
+
+	registry.RegisterMap("audit", map[string]interface{}{"source": "none"})
+	registry.Register("base", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return map[string]interface{}{"region": registry.GetEnvString("GONFIG_LOADER_REGION", "local")}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		// Test a loader combining the environment, another section and a Set call
+		registry.Register("service", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+			region := registry.MustGetString("base.region")
+			_ = registry.Set("audit.source", "service")
+			return map[string]interface{}{
+				"endpoint": fmt.Sprintf("https://%s.%s.example.com", registry.GetEnvString("GONFIG_LOADER_NAME", "api"), region),
+			}
+		})
+
+		// Test layers reading another section
+		registry.AddLayer("routing", 0, func(registry configContracts.ConfigRegistry) map[string]interface{} {
+			return map[string]interface{}{"region": registry.MustGetString("base.region"), "weight": 1}
+		})
+		registry.AddLayer("routing", 10, func(registry configContracts.ConfigRegistry) map[string]interface{} {
+			return map[string]interface{}{"weight": registry.GetEnvInt("GONFIG_LOADER_WEIGHT", 5)}
+		})
+
+		suite.T().Setenv("GONFIG_LOADER_REGION", "us-east")
+		suite.T().Setenv("GONFIG_LOADER_NAME", "web")
+		suite.NoError(registry.Refresh())
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		suite.FailNow("loader using the registry deadlocked")
+	}
+
+	// Test Refresh reloads base before routing and service, which read the new region
+	suite.Equal("https://web.us-east.example.com", registry.MustGetString("service.endpoint"))
+	suite.Equal("us-east", registry.MustGetString("routing.region"))
+	suite.Equal(5, registry.MustGetInt("routing.weight"))
+	priority, ok := registry.Source("routing.region")
+	suite.True(ok)
+	suite.Equal(0, priority)
+
+	// Test audit is reset before service is reloaded, which writes to it again
+	suite.Equal("service", registry.MustGetString("audit.source"))
+}
+
+func (suite *ConfigTestSuite) TestGetStringArrayOrDefault() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+	registry.RegisterMap("lists", map[string]interface{}{
+		"empty": "",
+		"blank": "  ",
+		"hosts": "a, b",
+		"none":  []string{},
+		"flag":  true,
+	})
+	fallback := []string{"localhost"}
+
+	// Test blank strings and missing paths return the default
+	for _, path := range []string{"lists.empty", "lists.blank", "lists.missing"} {
+		hosts, err := registry.GetStringArrayOrDefault(path, fallback)
+		suite.NoError(err, path)
+		suite.Equal(fallback, hosts, path)
+	}
+
+	// Test GetStringArray still reads an empty string as an empty list
+	hosts, err := registry.GetStringArray("lists.empty", fallback)
+	suite.NoError(err)
+	suite.Empty(hosts)
+
+	// Test other values, including empty slices, are read as usual
+	hosts, err = registry.GetStringArrayOrDefault("lists.hosts", fallback)
+	suite.NoError(err)
+	suite.Equal([]string{"a", "b"}, hosts)
+	hosts, err = registry.GetStringArrayOrDefault("lists.none", fallback)
+	suite.NoError(err)
+	suite.Empty(hosts)
+	_, err = registry.GetStringArrayOrDefault("lists.flag", fallback)
+	suite.True(errors.Is(err, gonfig.ErrTypeConversion))
+
+	// Test each call looks the path up once
+	obs := &recordingObserver{hits: map[string]int{}, misses: map[string]int{}}
+	registry.SetMetricsObserver(obs)
+	_, err = registry.GetStringArrayOrDefault("lists.hosts", fallback)
+	suite.NoError(err)
+	_, err = registry.GetStringArrayOrDefault("lists.missing", fallback)
+	suite.NoError(err)
+	suite.Equal(1, obs.hits["lists.hosts"])
+	suite.Equal(1, obs.misses["lists.missing"])
+}
+
+func (suite *ConfigTestSuite) TestUnmarshalUnexportedFields() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+	registry.RegisterMap("service", map[string]interface{}{
+		"name":   "api",
+		"secret": "hunter2",
+		"limits": map[string]interface{}{"rate": "fast"},
+	})
+
+	type Limits struct {
+		Rate int `config:"rate"`
+	}
+	type Service struct {
+		Name   string `config:"name"`
+		secret string `config:"secret"`
+		cache  map[string]string
+	}
+
+	// Test unexported fields are skipped rather than failing the whole struct
+	var service Service
+	suite.NoError(registry.Unmarshal("service", &service))
+	suite.Equal("api", service.Name)
+	suite.Empty(service.secret)
+	suite.Nil(service.cache)
+
+	// Test errors name the key, the struct field and the full path
+	var nested struct {
+		Name   string `config:"name"`
+		Limits Limits `config:"limits"`
+	}
+	err = registry.Unmarshal("service", &nested)
+	suite.Error(err)
+	suite.Contains(err.Error(), "error setting field 'limits' (Limits) at 'service.limits'")
+	suite.Contains(err.Error(), "error setting field 'rate' (Rate) at 'service.limits.rate'")
+	var pathErr *gonfig.PathError
+	suite.True(errors.As(err, &pathErr))
+	suite.Equal("service.limits", pathErr.Path)
+	suite.Equal("limits", pathErr.Segment)
+
+	err = registry.UnmarshalKey("service.limits", &Limits{})
+	suite.Error(err)
+	suite.Contains(err.Error(), "at 'service.limits.rate'")
+}
+
+func (suite *ConfigTestSuite) TestUnmarshalStrict() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+	registry.RegisterMap("database", map[string]interface{}{
+		"host":           "localhost",
+		"max_connectons": 10,
+		"credentials":    map[string]interface{}{"username": "app", "pasword": "secret"},
+		"pool":           map[string]interface{}{"size": 4, "idle": 2},
+		"backend":        map[string]interface{}{"kind": "unix", "addr": "/tmp/db.sock"},
+		"started_at":     "2024-01-02T03:04:05Z",
+	})
+
+	type Pool struct {
+		Size int `config:"size"`
+	}
+	type Timestamps struct {
+		StartedAt time.Time `config:"started_at"`
+	}
+	type Database struct {
+		Timestamps
+		Host           string  `config:"host"`
+		MaxConnections int     `config:"max_connections"`
+		Username       string  `config:"credentials.username"`
+		Pool           *Pool   `config:"pool"`
+		Backend        Backend `config:"backend"`
+	}
+
+	// Test misspelled keys are reported recursively, with the struct still populated
+	var db Database
+	err = registry.UnmarshalStrict("database", &db)
+	suite.Error(err)
+	suite.True(errors.Is(err, gonfig.ErrUnknownKey))
+	suite.Contains(err.Error(), "database.credentials.pasword, database.max_connectons, database.pool.idle")
+	suite.NotContains(err.Error(), "backend")
+	suite.NotContains(err.Error(), "started_at")
+	suite.Equal("localhost", db.Host)
+	suite.Equal("app", db.Username)
+	suite.Equal(4, db.Pool.Size)
+	suite.Equal("unix", db.Backend.Kind)
+
+	// Test Unmarshal still ignores unknown keys
+	suite.NoError(registry.Unmarshal("database", &Database{}))
+
+	// Test a struct using every key passes
+	var all struct {
+		Database
+		MaxConnections int `config:"max_connectons"`
+		Credentials    struct {
+			Username string `config:"username"`
+			Password string `config:"pasword"`
+		} `config:"credentials"`
+		Pool struct {
+			Size int `config:"size"`
+			Idle int `config:"idle"`
+		} `config:"pool"`
+	}
+	suite.NoError(registry.UnmarshalStrict("database", &all))
+}
+
+func (suite *ConfigTestSuite) TestSetFallback() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+	registry.EnableValueCache(true)
+	registry.RegisterMap("global", map[string]interface{}{"timeout": 30, "retries": 3})
+	registry.RegisterMap("defaults", map[string]interface{}{"timeout": 10})
+	registry.RegisterMap("billing", map[string]interface{}{"retries": 5})
+
+	// Test a missing path reads its fallback chain, and a present one ignores it
+	suite.NoError(registry.SetFallback("billing.timeout", "defaults.timeout"))
+	suite.NoError(registry.SetFallback("billing.retries", "global.retries"))
+	suite.NoError(registry.SetFallback("defaults.timeout", "global.timeout"))
+	suite.Equal(10, registry.MustGetInt("billing.timeout"))
+	suite.Equal(5, registry.MustGetInt("billing.retries"))
+
+	// Test changes to the fallback section are seen despite the value cache
+	suite.NoError(registry.Unset("defaults.timeout"))
+	suite.Equal(30, registry.MustGetInt("billing.timeout"))
+	value, origin, err := registry.Resolve("billing.timeout")
+	suite.NoError(err)
+	suite.Equal(30, value)
+	suite.Equal("loader:global", origin)
+
+	// Test fallbacks apply before accessor defaults, which apply when the chain is empty
+	timeout, err := registry.GetInt("billing.timeout", 60)
+	suite.NoError(err)
+	suite.Equal(30, timeout)
+	suite.NoError(registry.SetFallback("billing.port", "global.port"))
+	port, err := registry.GetInt("billing.port", 8080)
+	suite.NoError(err)
+	suite.Equal(8080, port)
+	_, err = registry.Get("billing.port")
+	suite.True(errors.Is(err, gonfig.ErrKeyNotFound))
+
+	// Test cycles are rejected, and an empty fallback path removes the fallback
+	err = registry.SetFallback("global.timeout", "billing.timeout")
+	suite.True(errors.Is(err, gonfig.ErrInvalidPath))
+	suite.Contains(err.Error(), "fallback cycle: global.timeout -> billing.timeout -> defaults.timeout -> global.timeout")
+	suite.Error(registry.SetFallback("global.timeout", "global.timeout"))
+	suite.NoError(registry.SetFallback("billing.timeout", ""))
+	_, err = registry.Get("billing.timeout")
+	suite.True(errors.Is(err, gonfig.ErrKeyNotFound))
+}
+
+func (suite *ConfigTestSuite) TestStringRedactsValues() {
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	suite.NoError(err)
+	registry.RegisterMap("database", map[string]interface{}{
+		"host":     "localhost",
+		"password": "hunter2",
+		"options":  map[string]interface{}{"token": "s3cr3t"},
+	})
+	registry.RegisterMap("app", map[string]interface{}{"name": "api"})
+	registry.Register("empty", func(registry configContracts.ConfigRegistry) map[string]interface{} {
+		return nil
+	})
+
+	// Test every format lists sections and key counts without values
+	expected := "gonfig.ConfigRegistry{app: 1 key, database: 3 keys, empty: nil}"
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		printed := fmt.Sprintf(format, registry)
+		suite.Equal(expected, printed, format)
+		suite.NotContains(printed, "hunter2", format)
+		suite.NotContains(printed, "s3cr3t", format)
+	}
+	suite.Equal(expected, fmt.Sprint(registry.ReadOnly()))
+
+	// Test Export returns every value as a copy
+	exported := registry.Export()
+	database := exported["database"].(map[string]interface{})
+	suite.Equal("hunter2", database["password"])
+	suite.Nil(exported["empty"])
+	database["options"].(map[string]interface{})["token"] = "changed"
+	suite.Equal("s3cr3t", registry.MustGetString("database.options.token"))
+}
@@ -0,0 +1,74 @@
+package config_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centraunit/gonfig"
+	"github.com/centraunit/gonfig/loaders/consul"
+	"github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsulLoader(t *testing.T) {
+	pairs := api.KVPairs{
+		{Key: "apps/web/", Value: nil},
+		{Key: "apps/web/name", Value: []byte("web")},
+		{Key: "apps/web/db/host", Value: []byte("db.internal")},
+		{Key: "apps/web/db/port", Value: []byte("5432")},
+	}
+	failing := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if failing {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		// The prefix is listed as a folder, so "apps/website" keys don't match
+		assert.Equal(t, "/v1/kv/apps/web/", req.URL.Path)
+		_ = json.NewEncoder(w).Encode(pairs)
+	}))
+	defer server.Close()
+
+	client, err := api.NewClient(&api.Config{Address: server.URL})
+	require.NoError(t, err)
+
+	registry, err := gonfig.GetConfigRegistry("testing")
+	require.NoError(t, err)
+
+	// Test initial load builds nested values
+	err = consul.Register(registry, "consul_web", "apps/web", client)
+	require.NoError(t, err)
+
+	host, err := registry.GetString("consul_web.db.host")
+	assert.NoError(t, err)
+	assert.Equal(t, "db.internal", host)
+
+	port, err := registry.GetInt("consul_web.db.port")
+	assert.NoError(t, err)
+	assert.Equal(t, 5432, port)
+
+	// Test Refresh re-fetches from Consul
+	pairs[1].Value = []byte("web-v2")
+	registry.Refresh()
+	name, err := registry.GetString("consul_web.name")
+	assert.NoError(t, err)
+	assert.Equal(t, "web-v2", name)
+
+	// Test failed refresh keeps previous values
+	failing = true
+	registry.Refresh()
+	name, err = registry.GetString("consul_web.name")
+	assert.NoError(t, err)
+	assert.Equal(t, "web-v2", name)
+
+	// Test connection errors are surfaced on registration
+	err = consul.Register(registry, "consul_broken", "apps/web", client)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error loading consul prefix 'apps/web/'")
+	_, err = registry.Get("consul_broken")
+	assert.ErrorIs(t, err, gonfig.ErrSectionNotFound)
+}
@@ -0,0 +1,243 @@
+package config_test
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/centraunit/gonfig"
+)
+
+// envHelperKey marks a re-executed test binary that initializes the registry once.
+// The registry is a process-wide singleton, so each scenario runs in its own process.
+const envHelperKey = "GONFIG_ENV_HELPER"
+
+// TestEnvHelper initializes the registry in a subprocess and reports the outcome on stdout.
+// It is skipped unless invoked by runEnvHelper.
+func TestEnvHelper(t *testing.T) {
+	if os.Getenv(envHelperKey) != "1" {
+		t.Skip("only runs as a subprocess")
+	}
+
+	if key := os.Getenv("GONFIG_ENV_KEY"); key != "" {
+		gonfig.EnvKey = key
+	}
+	_, err := gonfig.GetConfigRegistry(os.Getenv("GONFIG_ENV_ARG"))
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	fmt.Printf("ok loaded=%s\n", os.Getenv("GONFIG_LOADED"))
+	if names := os.Getenv("GONFIG_ENV_PRINT"); names != "" {
+		for _, name := range strings.Split(names, ",") {
+			fmt.Printf("%s=%s\n", name, os.Getenv(name))
+		}
+	}
+}
+
+// runEnvHelper runs TestEnvHelper in a subprocess with the given working directory,
+// env argument and environment variables, returning its output.
+func runEnvHelper(t *testing.T, dir, arg string, env ...string) string {
+	t.Helper()
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestEnvHelper$")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), envHelperKey+"=1", "GONFIG_ENV_ARG="+arg, "APP_ENV=")
+	cmd.Env = append(cmd.Env, env...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("helper failed: %v\n%s", err, out)
+	}
+	return string(out)
+}
+
+// TestEnvSelection tests choosing the environment from an environment variable
+func TestEnvSelection(t *testing.T) {
+	tests := []struct {
+		name     string
+		arg      string
+		env      []string
+		expected string
+	}{
+		{"explicit argument", "testing", nil, "ok"},
+		{"from APP_ENV", "", []string{"APP_ENV=testing"}, "ok"},
+		{"argument takes precedence", "testing", []string{"APP_ENV=invalid"}, "ok"},
+		{"custom key", "", []string{"GONFIG_ENV_KEY=SERVICE_ENV", "SERVICE_ENV=testing"}, "ok"},
+		{"neither set", "", nil, "error: env is required when initializing config registry: pass it or set APP_ENV"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := runEnvHelper(t, ".", tt.arg, tt.env...)
+			if !strings.Contains(out, tt.expected) {
+				t.Errorf("expected output containing %q, got:\n%s", tt.expected, out)
+			}
+		})
+	}
+}
+
+// writeEnvFiles creates a temporary directory containing the given env files.
+func writeEnvFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// TestCustomEnvNames tests loading env files for arbitrary environment names
+func TestCustomEnvNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		arg      string
+		expected string
+	}{
+		{"env specific file", map[string]string{".env.qa": "GONFIG_LOADED=qa", ".env": "GONFIG_LOADED=default"}, "qa", "ok loaded=qa"},
+		{"fallback to .env", map[string]string{".env": "GONFIG_LOADED=default"}, "sandbox", "ok loaded=default"},
+		{"standard env specific file", map[string]string{".env.production": "GONFIG_LOADED=production"}, "production", "ok loaded=production"},
+		{"missing file for custom env", nil, "sandbox", "ok loaded=\n"},
+		{"missing file for standard env", nil, "production", "ok loaded=\n"},
+		{"malformed file", map[string]string{".env.qa": "GONFIG_LOADED='unterminated"}, "qa", "error: error loading .env.qa file"},
+		{"invalid name", nil, "../qa", "error: invalid env: ../qa"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := runEnvHelper(t, writeEnvFiles(t, tt.files), tt.arg)
+			if !strings.Contains(out, tt.expected) {
+				t.Errorf("expected output containing %q, got:\n%s", tt.expected, out)
+			}
+		})
+	}
+}
+
+// TestMissingEnvFileLogging tests that a missing env file is only reported to the registry's logger
+func TestMissingEnvFileLogging(t *testing.T) {
+	// Nothing is written to the output without a logger
+	out := runEnvHelper(t, writeEnvFiles(t, nil), "sandbox")
+	if strings.Contains(out, "warning") || !strings.HasPrefix(out, "ok loaded=\n") {
+		t.Errorf("expected no warning without a logger, got:\n%s", out)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	var buf bytes.Buffer
+	_, err = gonfig.NewConfigRegistry("sandbox", gonfig.WithLogger(slog.New(slog.NewTextHandler(&buf, nil))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `level=WARN msg="env file not found" env=sandbox`; !strings.Contains(buf.String(), expected) {
+		t.Errorf("expected log containing %q, got:\n%s", expected, buf.String())
+	}
+}
+
+// TestEnvFileExpansion tests that env file values can reference other variables
+func TestEnvFileExpansion(t *testing.T) {
+	t.Run("chained variables in .env.testing", func(t *testing.T) {
+		out := runEnvHelper(t, ".", "testing", "GONFIG_ENV_PRINT=APP_HEALTH_URL,APP_LITERAL")
+		for _, expected := range []string{"APP_HEALTH_URL=http://localhost:8000/health", "APP_LITERAL=${APP_URL}"} {
+			if !strings.Contains(out, expected) {
+				t.Errorf("expected output containing %q, got:\n%s", expected, out)
+			}
+		}
+	})
+
+	t.Run("existing environment variables", func(t *testing.T) {
+		files := map[string]string{".env.qa": "GONFIG_ZONE=b\nGONFIG_LOADED=\"${GONFIG_REGION}/${GONFIG_ZONE}\"\n"}
+		out := runEnvHelper(t, writeEnvFiles(t, files), "qa", `GONFIG_REGION=eu "west"\$1`)
+		if expected := `ok loaded=eu "west"\$1/b`; !strings.Contains(out, expected) {
+			t.Errorf("expected output containing %q, got:\n%s", expected, out)
+		}
+	})
+}
+
+// TestReloadEnvOnRefresh tests that Refresh re-reads env files without overriding other variables
+func TestReloadEnvOnRefresh(t *testing.T) {
+	for _, key := range []string{"GONFIG_RELOAD_PORT", "GONFIG_RELOAD_REMOVED", "GONFIG_RELOAD_ADDED"} {
+		t.Cleanup(func() { os.Unsetenv(key) })
+	}
+	t.Setenv("GONFIG_RELOAD_HOST", "from-os")
+
+	dir := writeEnvFiles(t, map[string]string{
+		"app.env": "GONFIG_RELOAD_PORT=8080\nGONFIG_RELOAD_HOST=from-file\nGONFIG_RELOAD_REMOVED=yes\n",
+	})
+	path := filepath.Join(dir, "app.env")
+	registry, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry.RegisterEnvPrefix("reload", "GONFIG_RELOAD_")
+
+	content := "GONFIG_RELOAD_PORT=9090\nGONFIG_RELOAD_HOST=changed\nGONFIG_RELOAD_ADDED=new\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Env files are only read again once enabled
+	if err := registry.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if port, _ := registry.GetString("reload.port"); port != "8080" {
+		t.Errorf("expected port 8080 before enabling reloads, got %q", port)
+	}
+
+	registry.SetReloadEnvOnRefresh(true)
+	if err := registry.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"GONFIG_RELOAD_PORT":  "9090",
+		"GONFIG_RELOAD_HOST":  "from-os",
+		"GONFIG_RELOAD_ADDED": "new",
+	}
+	for key, value := range expected {
+		if actual := os.Getenv(key); actual != value {
+			t.Errorf("expected %s=%q, got %q", key, value, actual)
+		}
+	}
+	if _, ok := os.LookupEnv("GONFIG_RELOAD_REMOVED"); ok {
+		t.Error("expected GONFIG_RELOAD_REMOVED to be unset once removed from the file")
+	}
+	if port, _ := registry.GetString("reload.port"); port != "9090" {
+		t.Errorf("expected loaders to see port 9090, got %q", port)
+	}
+
+	// Variables changed by the application are no longer replaced by the file
+	os.Setenv("GONFIG_RELOAD_PORT", "7070")
+	if err := registry.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if port := os.Getenv("GONFIG_RELOAD_PORT"); port != "7070" {
+		t.Errorf("expected GONFIG_RELOAD_PORT to keep 7070, got %q", port)
+	}
+
+	// A missing file is reported, and the loaders still run
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv("GONFIG_RELOAD_ADDED", "direct")
+	err = registry.Refresh()
+	if err == nil || !strings.Contains(err.Error(), "error reloading env files") {
+		t.Errorf("expected an env file reload error, got %v", err)
+	}
+	if added, _ := registry.GetString("reload.added"); added != "direct" {
+		t.Errorf("expected loaders to run after a reload error, got %q", added)
+	}
+}
@@ -0,0 +1,143 @@
+package config_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/centraunit/gonfig"
+	"github.com/centraunit/gonfig/loaders/etcd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// fakeEtcdKV serves Get requests from a fixed set of key/values
+type fakeEtcdKV struct {
+	clientv3.KV
+	kvs []*mvccpb.KeyValue
+	err error
+}
+
+func (f *fakeEtcdKV) Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &clientv3.GetResponse{Header: &etcdserverpb.ResponseHeader{Revision: 1}, Kvs: f.kvs}, nil
+}
+
+// fakeEtcdWatcher streams watch responses sent on its channel
+type fakeEtcdWatcher struct {
+	clientv3.Watcher
+	events chan clientv3.WatchResponse
+}
+
+func (f *fakeEtcdWatcher) Watch(ctx context.Context, key string, opts ...clientv3.OpOption) clientv3.WatchChan {
+	out := make(chan clientv3.WatchResponse)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case resp := <-f.events:
+				out <- resp
+			}
+		}
+	}()
+	return out
+}
+
+func TestEtcdLoader(t *testing.T) {
+	kv := &fakeEtcdKV{kvs: []*mvccpb.KeyValue{
+		{Key: []byte("/apps/web/name"), Value: []byte("web")},
+		{Key: []byte("/apps/web/db/port"), Value: []byte("5432")},
+	}}
+	watcher := &fakeEtcdWatcher{events: make(chan clientv3.WatchResponse)}
+	client := &clientv3.Client{KV: kv, Watcher: watcher}
+
+	registry, err := gonfig.GetConfigRegistry("testing")
+	require.NoError(t, err)
+
+	changed := make(chan struct{}, 1)
+	err = etcd.Register(registry, "etcd_web", "/apps/web/", client, func() {
+		changed <- struct{}{}
+	})
+	require.NoError(t, err)
+	defer etcd.StopWatch(registry, "etcd_web")
+
+	// Test initial load builds nested values
+	port, err := registry.GetInt("etcd_web.db.port")
+	assert.NoError(t, err)
+	assert.Equal(t, 5432, port)
+
+	// Test watch events update the section and trigger callbacks
+	watcher.events <- clientv3.WatchResponse{Events: []*clientv3.Event{
+		{Type: clientv3.EventTypePut, Kv: &mvccpb.KeyValue{Key: []byte("/apps/web/name"), Value: []byte("web-v2")}},
+		{Type: clientv3.EventTypeDelete, Kv: &mvccpb.KeyValue{Key: []byte("/apps/web/db/port")}},
+	}}
+	select {
+	case <-changed:
+	case <-time.After(time.Second):
+		t.Fatal("onChange callback was not invoked")
+	}
+
+	name, err := registry.GetString("etcd_web.name")
+	assert.NoError(t, err)
+	assert.Equal(t, "web-v2", name)
+
+	_, err = registry.Get("etcd_web.db.port")
+	assert.ErrorIs(t, err, gonfig.ErrKeyNotFound)
+
+	// Test StopWatch stops applying updates
+	etcd.StopWatch(registry, "etcd_web")
+	select {
+	case watcher.events <- clientv3.WatchResponse{}:
+		t.Fatal("watch is still running after StopWatch")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Test load errors are surfaced on registration
+	kv.err = fmt.Errorf("connection refused")
+	err = etcd.Register(registry, "etcd_broken", "/apps/web/", client)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error loading etcd prefix '/apps/web/': connection refused")
+}
+
+func TestEtcdWatchPerRegistry(t *testing.T) {
+	kv := &fakeEtcdKV{kvs: []*mvccpb.KeyValue{{Key: []byte("/apps/web/name"), Value: []byte("web")}}}
+	first, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	require.NoError(t, err)
+	second, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	require.NoError(t, err)
+
+	firstWatcher := &fakeEtcdWatcher{events: make(chan clientv3.WatchResponse)}
+	require.NoError(t, etcd.Register(first, "features", "/apps/web/", &clientv3.Client{KV: kv, Watcher: firstWatcher}))
+	defer etcd.StopWatch(first, "features")
+	secondWatcher := &fakeEtcdWatcher{events: make(chan clientv3.WatchResponse)}
+	require.NoError(t, etcd.Register(second, "features", "/apps/web/", &clientv3.Client{KV: kv, Watcher: secondWatcher}))
+	defer etcd.StopWatch(second, "features")
+
+	// Test registering the same section in another registry keeps the first watch running
+	select {
+	case firstWatcher.events <- clientv3.WatchResponse{}:
+	case <-time.After(time.Second):
+		t.Fatal("watch of the first registry was stopped")
+	}
+
+	// Test failed registrations don't start a watch
+	sealed, err := gonfig.NewConfigRegistry("testing", gonfig.WithEnvFiles())
+	require.NoError(t, err)
+	sealed.Seal()
+	sealedWatcher := &fakeEtcdWatcher{events: make(chan clientv3.WatchResponse)}
+	err = etcd.Register(sealed, "features", "/apps/web/", &clientv3.Client{KV: kv, Watcher: sealedWatcher})
+	assert.ErrorIs(t, err, gonfig.ErrSealed)
+	select {
+	case sealedWatcher.events <- clientv3.WatchResponse{}:
+		t.Fatal("watch started for a failed registration")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
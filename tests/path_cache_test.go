@@ -17,10 +17,21 @@ func TestPathCache(t *testing.T) {
 	result := pc.Get(path)
 	assert.Equal(t, expected, result)
 
-	// Test cache hit (same result, should be cached)
+	// Test cache hit (same result, but a distinct slice)
 	result2 := pc.Get(path)
 	assert.Equal(t, expected, result2)
-	assert.Equal(t, &result[0], &result2[0], "Should return same slice from cache")
+	assert.NotSame(t, &result[0], &result2[0], "Should return a copy of the cached slice")
+}
+
+func TestPathCacheMutation(t *testing.T) {
+	pc := gonfig.NewPathCache()
+	path := "database.connections.mysql.host"
+
+	// Mutating a returned slice must not corrupt the cache
+	result := pc.Get(path)
+	result[0] = "mutated"
+
+	assert.Equal(t, []string{"database", "connections", "mysql", "host"}, pc.Get(path))
 }
 
 func BenchmarkPathCache(b *testing.B) {
@@ -46,3 +57,18 @@ func BenchmarkPathCache(b *testing.B) {
 		}
 	})
 }
+
+func TestPathCacheEscapedKeys(t *testing.T) {
+	pc := gonfig.NewPathCache()
+	expected := []string{"servers", "api.example.com", "port"}
+
+	// Test backslash-escaped dots
+	assert.Equal(t, expected, pc.Get("servers.api\\.example\\.com.port"))
+
+	// Test bracket-quoted keys
+	assert.Equal(t, expected, pc.Get(`servers["api.example.com"].port`))
+	assert.Equal(t, []string{"servers", "api.example.com"}, pc.Get(`servers["api.example.com"]`))
+
+	// Test cache hit for escaped form
+	assert.Equal(t, expected, pc.Get("servers.api\\.example\\.com.port"))
+}
@@ -0,0 +1,79 @@
+package config_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centraunit/gonfig"
+	"github.com/centraunit/gonfig/loaders/vault"
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultLoader(t *testing.T) {
+	password := "s3cret"
+	status := http.StatusOK
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		switch req.URL.Path {
+		case "/v1/secret/data/database":
+			_, _ = w.Write([]byte(`{"data":{"data":{"username":"app","password":"` + password + `"},"metadata":{"version":1}}}`))
+		case "/v1/kv/legacy":
+			_, _ = w.Write([]byte(`{"data":{"api_key":"legacy-key"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := vaultapi.NewClient(&vaultapi.Config{Address: server.URL})
+	require.NoError(t, err)
+	client.SetToken("test-token")
+
+	registry, err := gonfig.GetConfigRegistry("testing")
+	require.NoError(t, err)
+
+	// Test KV version 2 secrets are unwrapped
+	err = vault.Register(registry, "vault_db", "secret/data/database", client)
+	require.NoError(t, err)
+	value, err := registry.GetString("vault_db.password")
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", value)
+
+	// Test KV version 1 secrets are used as-is
+	err = vault.Register(registry, "vault_legacy", "kv/legacy", client)
+	require.NoError(t, err)
+	value, err = registry.GetString("vault_legacy.api_key")
+	assert.NoError(t, err)
+	assert.Equal(t, "legacy-key", value)
+
+	// Test Refresh re-reads rotated secrets
+	password = "rotated"
+	registry.Refresh()
+	value, err = registry.GetString("vault_db.password")
+	assert.NoError(t, err)
+	assert.Equal(t, "rotated", value)
+
+	// Test missing secrets are surfaced
+	err = vault.Register(registry, "vault_missing", "secret/data/missing", client)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "vault secret not found: 'secret/data/missing'")
+
+	// Test auth errors are surfaced and keep previous values on Refresh
+	status = http.StatusForbidden
+	err = vault.Register(registry, "vault_denied", "secret/data/database", client)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "permission denied reading vault secret 'secret/data/database'")
+
+	registry.Refresh()
+	value, err = registry.GetString("vault_db.password")
+	assert.NoError(t, err)
+	assert.Equal(t, "rotated", value)
+}
@@ -0,0 +1,176 @@
+package gonfig
+
+import (
+	"sync"
+
+	configContracts "github.com/centraunit/gonfig/contracts"
+)
+
+// transaction is the staging view passed to a Transaction callback.
+// Reads are served by a read-only view of the staging registry, while writes are
+// applied to the staging registry and recorded for replay against the live one.
+type transaction struct {
+	configContracts.ConfigRegistry
+
+	staging *ConfigRegistry
+	ops     []func(r *ConfigRegistry) error
+	mu      sync.Mutex
+}
+
+// Transaction runs fn with a staging view of the registry and commits the writes it
+// makes atomically. The view reads the configuration as it was when the transaction
+// started, plus the transaction's own writes. If fn returns an error, or a write fails
+// when it is applied to the registry, nothing is committed and the error is returned.
+// Only Set, SetMany, the typed Set variants, GetOrSet, Unset and MergeFrom are staged; other
+// mutating methods behave as on a ReadOnly view.
+// Example: Transaction(func(tx contracts.ConfigRegistry) error { return tx.Set("app.port", 8080) })
+func (r *ConfigRegistry) Transaction(fn func(tx configContracts.ConfigRegistry) error) error {
+	r.mu.RLock()
+	staging := &ConfigRegistry{
+		configs:        make(map[string]map[string]interface{}, len(r.configs)),
+		loaders:        make(map[string]configContracts.ConfigLoaderE),
+		pathCache:      r.pathCache,
+		schema:         r.schema,
+		bindings:       make(map[string]func() (interface{}, bool), len(r.bindings)),
+		bound:          make(map[string][]interface{}),
+		hooks:          r.hooks,
+		enforced:       r.enforced,
+		fallbacks:      r.fallbacks,
+		arraySeparator: r.arraySeparator,
+		arrayOmitEmpty: r.arrayOmitEmpty,
+		strictTypes:    r.strictTypes,
+		layers:         r.layers,
+		origins:        copyOrigins(r.origins),
+
+		envOverridePrefix: r.envOverridePrefix,
+	}
+	// Writes are copy-on-write, so sharing the section maps is safe
+	for name, config := range r.configs {
+		staging.configs[name] = config
+	}
+	for path, binding := range r.bindings {
+		staging.bindings[path] = binding
+	}
+	r.mu.RUnlock()
+
+	tx := &transaction{
+		ConfigRegistry: staging.ReadOnly(),
+		staging:        staging,
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return r.commit(tx.ops)
+}
+
+// commit applies the recorded writes under a single write lock.
+// If any write fails, every section is restored to its state before the commit.
+func (r *ConfigRegistry) commit(ops []func(r *ConfigRegistry) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkSealed("*"); err != nil {
+		return err
+	}
+	return r.apply(ops)
+}
+
+// apply runs the writes in order, restoring every section if one fails.
+// The caller must hold the write lock.
+func (r *ConfigRegistry) apply(ops []func(r *ConfigRegistry) error) error {
+	previous := make(map[string]map[string]interface{}, len(r.configs))
+	for name, config := range r.configs {
+		previous[name] = config
+	}
+	previousOrigins := copyOrigins(r.origins)
+
+	for _, op := range ops {
+		if err := op(r); err != nil {
+			r.configs = previous
+			r.origins = previousOrigins
+			r.invalidateAll()
+			return err
+		}
+	}
+	return nil
+}
+
+// record applies a write to the staging registry and, if it succeeds, records it for commit.
+func (t *transaction) record(op func(r *ConfigRegistry) error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.staging.mu.Lock()
+	err := op(t.staging)
+	t.staging.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	t.ops = append(t.ops, op)
+	return nil
+}
+
+// Set stages a configuration update.
+func (t *transaction) Set(path string, value interface{}) error {
+	return t.record(func(r *ConfigRegistry) error {
+		return r.set(path, value)
+	})
+}
+
+// SetMany stages several configuration updates, all or nothing.
+func (t *transaction) SetMany(values map[string]interface{}) error {
+	ops := setOps(values)
+	return t.record(func(r *ConfigRegistry) error {
+		return r.apply(ops)
+	})
+}
+
+// SetString stages a string update.
+func (t *transaction) SetString(path string, value string) error {
+	return t.Set(path, value)
+}
+
+// SetInt stages an integer update.
+func (t *transaction) SetInt(path string, value int) error {
+	return t.Set(path, value)
+}
+
+// SetBool stages a boolean update.
+func (t *transaction) SetBool(path string, value bool) error {
+	return t.Set(path, value)
+}
+
+// SetFloat stages a float64 update.
+func (t *transaction) SetFloat(path string, value float64) error {
+	return t.Set(path, value)
+}
+
+// GetOrSet returns the staged value at path, or stages the given value if the path doesn't exist.
+func (t *transaction) GetOrSet(path string, value interface{}) (interface{}, error) {
+	existing, err := t.Get(path)
+	if err == nil {
+		return existing, nil
+	}
+	if !isMissing(err) {
+		return nil, err
+	}
+	if err := t.Set(path, value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Unset stages the removal of a configuration value.
+func (t *transaction) Unset(path string) error {
+	return t.record(func(r *ConfigRegistry) error {
+		return r.unset(path)
+	})
+}
+
+// MergeFrom stages a deep merge of the given sections.
+func (t *transaction) MergeFrom(other map[string]map[string]interface{}) {
+	_ = t.record(func(r *ConfigRegistry) error {
+		r.mergeFrom(other)
+		return nil
+	})
+}
@@ -0,0 +1,102 @@
+package gonfig
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// validateTag checks a struct field against the rules of its `validate` tag.
+// Rules are comma separated: `min=N` and `max=N` bound numbers and durations, or the
+// length of strings, slices and maps, while `oneof=a b c` restricts the field to a
+// space separated set of values.
+// Example: `validate:"min=1,max=65535"`
+func validateTag(field reflect.Value, rules string) error {
+	for _, rule := range strings.Split(rules, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		name, arg, _ := strings.Cut(rule, "=")
+		var err error
+		switch name {
+		case "min":
+			err = checkBound(field, arg, func(value, bound float64) bool { return value >= bound }, "less than")
+		case "max":
+			err = checkBound(field, arg, func(value, bound float64) bool { return value <= bound }, "greater than")
+		case "oneof":
+			err = checkOneOf(field, strings.Fields(arg))
+		default:
+			err = fmt.Errorf("unknown rule")
+		}
+		if err != nil {
+			return fmt.Errorf("validation rule '%s' failed: %w", rule, err)
+		}
+	}
+	return nil
+}
+
+// checkBound compares a field against a numeric bound. Durations accept bounds such
+// as "1s", and strings, slices and maps are bounded by their length.
+func checkBound(field reflect.Value, arg string, ok func(value, bound float64) bool, violation string) error {
+	if field.Type() == durationType {
+		bound, err := time.ParseDuration(arg)
+		if err != nil {
+			return fmt.Errorf("invalid duration bound '%s'", arg)
+		}
+		if d := time.Duration(field.Int()); !ok(float64(d), float64(bound)) {
+			return fmt.Errorf("%v is %s %v", d, violation, bound)
+		}
+		return nil
+	}
+
+	bound, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return fmt.Errorf("invalid bound '%s'", arg)
+	}
+
+	var value float64
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value = float64(field.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value = float64(field.Uint())
+	case reflect.Float32, reflect.Float64:
+		value = field.Float()
+	case reflect.String, reflect.Slice, reflect.Map:
+		if length := field.Len(); !ok(float64(length), bound) {
+			return fmt.Errorf("length %d is %s %s", length, violation, arg)
+		}
+		return nil
+	default:
+		return fmt.Errorf("not supported for type %v", field.Type())
+	}
+
+	if !ok(value, bound) {
+		return fmt.Errorf("%v is %s %s", field.Interface(), violation, arg)
+	}
+	return nil
+}
+
+// checkOneOf checks that a field's value matches one of the allowed values.
+func checkOneOf(field reflect.Value, allowed []string) error {
+	switch field.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+	default:
+		return fmt.Errorf("not supported for type %v", field.Type())
+	}
+
+	value := fmt.Sprint(field.Interface())
+	for _, candidate := range allowed {
+		if value == candidate {
+			return nil
+		}
+	}
+	return fmt.Errorf("'%s' is not one of [%s]", value, strings.Join(allowed, " "))
+}